@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azqr/internal/compare"
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers/excel"
+	"github.com/Azure/azqr/internal/renderers/json"
+	"github.com/Azure/azqr/internal/renderers/markdown"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	compareCmd.Flags().StringP("filters", "e", "", "Filters file (YAML format) applied to checkpoint directories")
+	compareCmd.Flags().StringP("output-name", "o", "", "Output file name without extension")
+	compareCmd.Flags().BoolP("xlsx", "", true, "Create Excel report (default)")
+	compareCmd.Flags().BoolP("json", "", false, "Create JSON report files")
+	compareCmd.Flags().BoolP("markdown", "", false, "Create Markdown report")
+	rootCmd.AddCommand(compareCmd)
+}
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <before> <after>",
+	Short: "Compare the results of two scans",
+	Long:  "Compare the results of two scans, given as the output name of their JSON report files or as their checkpoint directories",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		filtersFile, _ := cmd.Flags().GetString("filters")
+		outputName, _ := cmd.Flags().GetString("output-name")
+		xlsx, _ := cmd.Flags().GetBool("xlsx")
+		js, _ := cmd.Flags().GetBool("json")
+		md, _ := cmd.Flags().GetBool("markdown")
+
+		filters, err := models.LoadFilters(filtersFile, []string{})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load filters")
+		}
+
+		before, err := compare.LoadSnapshot(args[0], filters)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load scan results")
+		}
+		after, err := compare.LoadSnapshot(args[1], filters)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load scan results")
+		}
+
+		data := compare.Compare(before, after)
+		data.Before, data.After = args[0], args[1]
+		data.OutputFileName = outputName
+		if data.OutputFileName == "" {
+			data.OutputFileName = fmt.Sprintf("azqr_compare_%s", time.Now().Format("2006_01_02_T150405"))
+		}
+
+		log.Info().Msgf("%d new findings, %d resolved findings, %d added resources, %d removed resources and %d SLA changes",
+			len(data.NewFindings), len(data.ResolvedFindings), len(data.AddedResources), len(data.RemovedResources), len(data.SLAChanges))
+
+		if xlsx {
+			if err := excel.CreateCompareReport(data); err != nil {
+				log.Fatal().Err(err).Msg("Failed to render reports")
+			}
+		}
+
+		if js {
+			if err := json.CreateCompareReport(data); err != nil {
+				log.Fatal().Err(err).Msg("Failed to render reports")
+			}
+		}
+
+		if md {
+			if err := markdown.CreateCompareReport(data); err != nil {
+				log.Fatal().Err(err).Msg("Failed to render reports")
+			}
+		}
+	},
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/Azure/azqr/internal/az"
 	"github.com/spf13/cobra"
 
 	"github.com/rs/zerolog"
@@ -33,5 +34,8 @@ func Execute() {
 
 	log.Logger = zerolog.New(output).With().Timestamp().Logger()
 
+	// report the build version in the User-Agent header of the ARM requests
+	az.Version = version
+
 	cobra.CheckErr(rootCmd.Execute())
 }
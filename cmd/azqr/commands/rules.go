@@ -6,12 +6,16 @@ package commands
 import (
 	"fmt"
 
+	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	rootCmd.PersistentFlags().BoolP("json", "j", false, "Output rules list in JSON format")
+	rulesCmd.Flags().StringP("rules-dir", "", "", "Directory of custom YAML rules to include in the list")
+	rulesCmd.Flags().StringArrayP("rule-pack", "", []string{}, "Directory of recommendation YAML and KQL files in the APRL format to include in the list (can be used multiple times)")
 	rootCmd.AddCommand(rulesCmd)
 }
 
@@ -22,7 +26,19 @@ var rulesCmd = &cobra.Command{
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		oj, _ := cmd.Flags().GetBool("json")
-		output := renderers.GetAllRecommendations(!oj)
+		rulesDir, _ := cmd.Flags().GetString("rules-dir")
+		rulePacks, _ := cmd.Flags().GetStringArray("rule-pack")
+
+		var customRules models.CustomRules
+		if rulesDir != "" {
+			var err error
+			customRules, err = models.LoadCustomRules(rulesDir)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to load custom rules")
+			}
+		}
+
+		output := renderers.GetAllRecommendations(!oj, customRules, rulePacks)
 		fmt.Println(output)
 	},
 }
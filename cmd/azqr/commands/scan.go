@@ -4,8 +4,16 @@
 package commands
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/Azure/azqr/internal"
+	"github.com/Azure/azqr/internal/gate"
 	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/recording"
+	"github.com/rs/zerolog/log"
 
 	"github.com/spf13/cobra"
 )
@@ -20,12 +28,25 @@ func init() {
 	scanCmd.PersistentFlags().BoolP("xslx", "", true, "Create Excel report (default)")
 	scanCmd.PersistentFlags().BoolP("json", "", false, "Create JSON report files")
 	scanCmd.PersistentFlags().BoolP("csv", "", false, "Create CSV report files")
+	scanCmd.PersistentFlags().BoolP("sarif", "", false, "Create SARIF report file")
 	scanCmd.PersistentFlags().StringP("output-name", "o", "", "Output file name without extension")
 	scanCmd.PersistentFlags().BoolP("mask", "m", true, "Mask the subscription id in the report (default)")
 	scanCmd.PersistentFlags().BoolP("azure-cli-credential", "f", false, "Force the use of Azure CLI Credential")
 	scanCmd.PersistentFlags().BoolP("debug", "", false, "Set log level to debug")
 	scanCmd.PersistentFlags().StringP("filters", "e", "", "Filters file (YAML format)")
 	scanCmd.PersistentFlags().BoolP("azqr", "", true, "Scan Azure Quick Review Recommendations (default)")
+	scanCmd.PersistentFlags().StringP("checkpoint", "", "", "Directory where results are saved as they finish, to resume an interrupted scan")
+	scanCmd.PersistentFlags().StringP("resume", "", "", "Resume an interrupted scan from a checkpoint directory")
+	scanCmd.PersistentFlags().IntP("parallel-subscriptions", "", 1, "Number of subscriptions scanned at the same time")
+	scanCmd.PersistentFlags().StringP("record", "", "", "Record the scrubbed HTTP exchanges of the scan to a fixture file")
+	scanCmd.PersistentFlags().StringP("replay", "", "", "Replay a scan offline from a fixture file created with --record")
+	scanCmd.PersistentFlags().StringP("cloud", "", "", "Azure cloud: AzurePublic, AzureChina or AzureUSGovernment (default: AZURE_ENVIRONMENT or AzurePublic)")
+	scanCmd.PersistentFlags().StringP("rules-dir", "", "", "Directory of custom YAML rules evaluated alongside the built-in recommendations")
+	scanCmd.PersistentFlags().StringArrayP("rule-pack", "", []string{}, "Directory, e.g. a git checkout, of recommendation YAML and KQL files in the APRL format (can be used multiple times)")
+	scanCmd.PersistentFlags().StringP("baseline", "", "", "Baseline file (JSON format) to split the findings into new, existing and resolved")
+	scanCmd.PersistentFlags().StringP("write-baseline", "", "", "Write the findings to a baseline file (JSON format) accepting them in later scans")
+	scanCmd.PersistentFlags().StringP("trends-file", "", "", "Append the metrics of the scan to a trend store (JSON Lines format) read by azqr trends")
+	scanCmd.PersistentFlags().StringArrayP("fail-on", "", []string{}, "Exit with code 3 if the findings meet a condition, e.g. impact=High,count>0 or category=Security (can be used multiple times)")
 
 	rootCmd.AddCommand(scanCmd)
 }
@@ -52,14 +73,49 @@ func scan(cmd *cobra.Command, scannerKeys []string) {
 	xlsx, _ := cmd.Flags().GetBool("xslx")
 	csv, _ := cmd.Flags().GetBool("csv")
 	json, _ := cmd.Flags().GetBool("json")
+	sarif, _ := cmd.Flags().GetBool("sarif")
 	mask, _ := cmd.Flags().GetBool("mask")
 	debug, _ := cmd.Flags().GetBool("debug")
 	forceAzureCliCredential, _ := cmd.Flags().GetBool("azure-cli-credential")
 	filtersFile, _ := cmd.Flags().GetString("filters")
 	useAzqr, _ := cmd.Flags().GetBool("azqr")
+	checkpointDir, _ := cmd.Flags().GetString("checkpoint")
+	resumeDir, _ := cmd.Flags().GetString("resume")
+	cloudName, _ := cmd.Flags().GetString("cloud")
+	rulesDir, _ := cmd.Flags().GetString("rules-dir")
+	rulePacks, _ := cmd.Flags().GetStringArray("rule-pack")
+	parallelSubscriptions, _ := cmd.Flags().GetInt("parallel-subscriptions")
+	recordFile, _ := cmd.Flags().GetString("record")
+	replayFile, _ := cmd.Flags().GetString("replay")
+	baselineFile, _ := cmd.Flags().GetString("baseline")
+	writeBaselineFile, _ := cmd.Flags().GetString("write-baseline")
+	trendsFile, _ := cmd.Flags().GetString("trends-file")
+	failOn, _ := cmd.Flags().GetStringArray("fail-on")
+
+	if checkpointDir != "" && resumeDir != "" && checkpointDir != resumeDir {
+		log.Fatal().Msg("--checkpoint and --resume must use the same directory")
+	}
+
+	resume := resumeDir != ""
+	if resume {
+		checkpointDir = resumeDir
+	}
 
 	// load filters
-	filters := models.LoadFilters(filtersFile, scannerKeys)
+	filters, err := models.LoadFilters(filtersFile, scannerKeys)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to load filters")
+	}
+
+	// parse quality gate
+	conditions := []*gate.Condition{}
+	for _, expression := range failOn {
+		c, err := gate.Parse(expression)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to parse quality gate")
+		}
+		conditions = append(conditions, c)
+	}
 
 	params := internal.ScanParams{
 		ManagementGroups:        managementGroups,
@@ -72,14 +128,64 @@ func scan(cmd *cobra.Command, scannerKeys []string) {
 		Cost:                    cost,
 		Csv:                     csv,
 		Json:                    json,
+		Sarif:                   sarif,
 		Mask:                    mask,
 		Debug:                   debug,
 		ScannerKeys:             scannerKeys,
 		ForceAzureCliCredential: forceAzureCliCredential,
 		Filters:                 filters,
 		UseAzqrRecommendations:  useAzqr,
+		CheckpointDir:           checkpointDir,
+		Resume:                  resume,
+		Cloud:                   cloudName,
+		RulesDir:                rulesDir,
+		RulePacks:               rulePacks,
+		ParallelSubscriptions:   parallelSubscriptions,
+		Baseline:                baselineFile,
+		WriteBaseline:           writeBaselineFile,
+		TrendsFile:              trendsFile,
+		FailOn:                  conditions,
+	}
+
+	if recordFile != "" && replayFile != "" {
+		log.Fatal().Msg("--record and --replay cannot be used together")
+	}
+
+	if recordFile != "" {
+		recorder, err := recording.NewRecorder(recordFile, nil)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to start recording")
+		}
+		defer func() {
+			_ = recorder.Close()
+		}()
+		params.Transport = recorder
+	}
+
+	if replayFile != "" {
+		replayer, err := recording.NewReplayer(replayFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load recording")
+		}
+		params.Transport = replayer
+		params.Credential = recording.FakeCredential{}
 	}
 
 	scanner := internal.Scanner{}
-	scanner.Scan(&params)
+	scanner.Scan(interruptibleContext(), &params)
+}
+
+// interruptibleContext returns a context that is cancelled on the first SIGINT or SIGTERM,
+// so the scan can stop and save its partial results. A second signal terminates the process.
+func interruptibleContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		signal.Stop(signals)
+		log.Warn().Msgf("Received %s. Stopping scan and saving partial results. Press Ctrl+C again to exit immediately", sig)
+		cancel()
+	}()
+	return ctx
 }
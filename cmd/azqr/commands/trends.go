@@ -0,0 +1,59 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azqr/internal/renderers/excel"
+	"github.com/Azure/azqr/internal/renderers/html"
+	"github.com/Azure/azqr/internal/trends"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	trendsCmd.Flags().StringP("output-name", "o", "", "Output file name without extension")
+	trendsCmd.Flags().BoolP("xlsx", "", true, "Create Excel report (default)")
+	trendsCmd.Flags().BoolP("html", "", false, "Create HTML report")
+	rootCmd.AddCommand(trendsCmd)
+}
+
+var trendsCmd = &cobra.Command{
+	Use:   "trends <trends-file>",
+	Short: "Render the weekly trends of the scans",
+	Long:  "Render the weekly trends of the scans appended to a trend store with azqr scan --trends-file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		outputName, _ := cmd.Flags().GetString("output-name")
+		xlsx, _ := cmd.Flags().GetBool("xlsx")
+		h, _ := cmd.Flags().GetBool("html")
+
+		records, err := trends.Load(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load trend store")
+		}
+
+		data := trends.Weekly(records)
+		data.OutputFileName = outputName
+		if data.OutputFileName == "" {
+			data.OutputFileName = fmt.Sprintf("azqr_trends_%s", time.Now().Format("2006_01_02_T150405"))
+		}
+
+		log.Info().Msgf("%d scans over %d weeks and %d subscriptions", len(records), len(data.Weeks), len(data.Subscriptions))
+
+		if xlsx {
+			if err := excel.CreateTrendsReport(data); err != nil {
+				log.Fatal().Err(err).Msg("Failed to render reports")
+			}
+		}
+
+		if h {
+			if err := html.CreateTrendsReport(data); err != nil {
+				log.Fatal().Err(err).Msg("Failed to render reports")
+			}
+		}
+	},
+}
@@ -4,6 +4,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Azure/azqr/internal"
@@ -12,6 +13,7 @@ import (
 	"github.com/invopop/jsonschema"
 	mcp_golang "github.com/metoro-io/mcp-golang"
 	"github.com/metoro-io/mcp-golang/transport/stdio"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
@@ -67,7 +69,7 @@ func mcp(cmd *cobra.Command) {
 		`List all supported recommendations. This command returns details of the Recommendations
 		supported by Azure Quick Review (azqr). Use this to explore recommendations per id, category, impact and resource type.`,
 		func(arguments EmptyArguments) (*mcp_golang.ToolResponse, error) {
-			output := renderers.GetAllRecommendations(true)
+			output := renderers.GetAllRecommendations(true, nil, nil)
 			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
 		},
 	)
@@ -83,7 +85,11 @@ func mcp(cmd *cobra.Command) {
 
 			go func() {
 				scannerKeys := []string{arguments.ServiceKey}
-				filters := models.LoadFilters("", scannerKeys)
+				filters, err := models.LoadFilters("", scannerKeys)
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to load filters")
+					return
+				}
 				params := internal.NewScanParams()
 				params.Cost = false
 				params.Defender = false
@@ -91,7 +97,7 @@ func mcp(cmd *cobra.Command) {
 				params.ScannerKeys = scannerKeys
 				params.Filters = filters
 				scanner := internal.Scanner{}
-				scanner.Scan(params)
+				scanner.Scan(context.Background(), params)
 			}()
 
 			return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(output)), nil
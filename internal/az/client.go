@@ -0,0 +1,45 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package az
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+)
+
+const moduleName = "azqr"
+
+// Version is the azqr version reported in the User-Agent header of the ARM requests.
+// The azqr and MCP server commands set it to their build version.
+var Version = "dev"
+
+var (
+	// semver is the module version format required by the azcore pipeline
+	semver = regexp.MustCompile(`^v\d+\.\d+\.\d+(?:-[a-zA-Z0-9_.-]+)?$`)
+	// preRelease are the characters not allowed in the pre-release part of a module version
+	preRelease = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+)
+
+// NewClient creates a client for the Azure Resource Manager REST APIs that azqr calls without an SDK client.
+// Its pipeline renews access tokens before they expire and applies the same retry, proxy and telemetry options as the ARM SDK clients.
+func NewClient(cred azcore.TokenCredential, options *arm.ClientOptions) (*arm.Client, error) {
+	client, err := arm.NewClient(moduleName, moduleVersion(Version), cred, options)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// moduleVersion returns the version as a semantic version prefixed with v, e.g. v2.7.3.
+// Builds without a release version, e.g. dev, are reported as v0.0.0-dev.
+func moduleVersion(version string) string {
+	v := "v" + strings.TrimPrefix(version, "v")
+	if semver.MatchString(v) {
+		return v
+	}
+	return "v0.0.0-" + preRelease.ReplaceAllString(version, "-")
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package az
+
+import (
+	"testing"
+)
+
+func TestModuleVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"2.7.3", "v2.7.3"},
+		{"v2.7.3", "v2.7.3"},
+		{"2.8.0-alpha.0.12", "v2.8.0-alpha.0.12"},
+		{"dev", "v0.0.0-dev"},
+		{"local build", "v0.0.0-local-build"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := moduleVersion(tt.version)
+			if v != tt.want {
+				t.Errorf("moduleVersion(%s) = %s, want %s", tt.version, v, tt.want)
+			}
+			if !semver.MatchString(v) {
+				t.Errorf("moduleVersion(%s) = %s is not accepted by the azcore pipeline", tt.version, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package az
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+// EnvironmentVariable is read to select the cloud when no cloud name is given
+const EnvironmentVariable = "AZURE_ENVIRONMENT"
+
+// CloudNames lists the supported cloud names
+var CloudNames = []string{"AzurePublic", "AzureChina", "AzureUSGovernment"}
+
+// ParseCloud returns the configuration of the named cloud. Names are case insensitive and also accept
+// the Azure CLI and AZURE_ENVIRONMENT spellings (e.g. AzureCloud, AzureChinaCloud, AzureUSGovernmentCloud).
+// If name is empty the AZURE_ENVIRONMENT environment variable is used, defaulting to Azure Public Cloud.
+func ParseCloud(name string) (cloud.Configuration, error) {
+	if name == "" {
+		name = os.Getenv(EnvironmentVariable)
+	}
+
+	switch strings.ToLower(name) {
+	case "", "azurepublic", "azurecloud", "azurepubliccloud", "public":
+		return cloud.AzurePublic, nil
+	case "azurechina", "azurechinacloud", "china":
+		return cloud.AzureChina, nil
+	case "azureusgovernment", "azureusgovernmentcloud", "usgovernment", "usgov":
+		return cloud.AzureGovernment, nil
+	default:
+		return cloud.Configuration{}, fmt.Errorf("unsupported cloud %q. Supported clouds: %s", name, strings.Join(CloudNames, ", "))
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package checkpoint
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal/models"
+)
+
+type (
+	// Store persists the results of a scan as they finish, so an interrupted scan can be resumed.
+	// A nil Store is valid and neither saves nor loads anything.
+	Store struct {
+		dir string
+	}
+
+	// Manifest - Parameters of the scan that created the checkpoint
+	Manifest struct {
+		ManagementGroups []string `json:"managementGroups"`
+		Subscriptions    []string `json:"subscriptions"`
+		ResourceGroups   []string `json:"resourceGroups"`
+		ScannerKeys      []string `json:"scannerKeys"`
+		// Filters is the hash of the filters that change the checkpointed results, see HashFilters
+		Filters   string   `json:"filters"`
+		Cloud     string   `json:"cloud"`
+		Defender  bool     `json:"defender"`
+		Advisor   bool     `json:"advisor"`
+		Cost      bool     `json:"cost"`
+		Azqr      bool     `json:"azqr"`
+		Aprl      bool     `json:"aprl"`
+		RulesDir  string   `json:"rulesDir"`
+		RulePacks []string `json:"rulePacks"`
+	}
+
+	// SubscriptionResult - Results of a subscription scan
+	SubscriptionResult struct {
+		SubscriptionID   string                     `json:"subscriptionId"`
+		SubscriptionName string                     `json:"subscriptionName"`
+		Azqr             []models.AzqrServiceResult `json:"azqr"`
+		Cost             *models.CostResult         `json:"cost"`
+		Errors           []models.ScanError         `json:"errors"`
+	}
+
+	// AprlResult - Results of the Azure Resource Graph recommendations scan
+	AprlResult struct {
+		Results []models.AprlResult `json:"results"`
+		Errors  []models.ScanError  `json:"errors"`
+	}
+
+	// ResourcesResult - Inventory of the scanned and excluded resources
+	ResourcesResult struct {
+		Resources []*models.Resource `json:"resources"`
+		Excluded  []*models.Resource `json:"excluded"`
+	}
+
+	// Phase - Name of a tenant wide scan phase
+	Phase string
+)
+
+const (
+	PhaseResources               Phase = "resources"
+	PhaseAprl                    Phase = "aprl"
+	PhaseDiagnosticSettings      Phase = "diagnosticSettings"
+	PhaseAdvisor                 Phase = "advisor"
+	PhaseDefender                Phase = "defender"
+	PhaseDefenderRecommendations Phase = "defenderRecommendations"
+
+	manifestFile     = "manifest.json"
+	subscriptionsDir = "subscriptions"
+)
+
+// phases are all the tenant wide phases, removed when a checkpoint is reused without resuming
+var phases = []Phase{PhaseResources, PhaseAprl, PhaseDiagnosticSettings, PhaseAdvisor, PhaseDefender, PhaseDefenderRecommendations}
+
+// NewStore creates a checkpoint store in dir, creating the directory if needed.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Join(dir, subscriptionsDir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory %s: %w", dir, err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Dir returns the checkpoint directory
+func (s *Store) Dir() string {
+	if s == nil {
+		return ""
+	}
+	return s.dir
+}
+
+// Open validates the checkpoint manifest against the current scan.
+// When resume is false the results of any previous scan are removed and the manifest is (re)written.
+func (s *Store) Open(manifest Manifest, resume bool) error {
+	if s == nil {
+		return nil
+	}
+
+	if resume {
+		var existing Manifest
+		found, err := s.load(manifestFile, &existing)
+		if err != nil {
+			return err
+		}
+		if found {
+			if !reflect.DeepEqual(normalize(existing), normalize(manifest)) {
+				return fmt.Errorf("checkpoint in %s was created with different scan parameters", s.dir)
+			}
+			return nil
+		}
+	}
+
+	if err := s.clear(); err != nil {
+		return err
+	}
+	return s.save(manifestFile, manifest)
+}
+
+// clear removes the results of a previous scan
+func (s *Store) clear() error {
+	files := []string{}
+	for _, phase := range phases {
+		files = append(files, filepath.Join(s.dir, string(phase)+".json"))
+	}
+	subscriptions, err := filepath.Glob(filepath.Join(s.dir, subscriptionsDir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to clear checkpoint %s: %w", s.dir, err)
+	}
+	files = append(files, subscriptions...)
+
+	for _, f := range files {
+		if err := os.Remove(f); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to clear checkpoint %s: %w", s.dir, err)
+		}
+	}
+	return nil
+}
+
+// SavePhase persists the results of a tenant wide phase
+func (s *Store) SavePhase(phase Phase, v interface{}) error {
+	if s == nil {
+		return nil
+	}
+	return s.save(string(phase)+".json", v)
+}
+
+// LoadPhase loads the results of a tenant wide phase. Returns false if the phase was not checkpointed.
+func (s *Store) LoadPhase(phase Phase, v interface{}) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	return s.load(string(phase)+".json", v)
+}
+
+// SaveSubscription persists the results of a subscription scan
+func (s *Store) SaveSubscription(r *SubscriptionResult) error {
+	if s == nil {
+		return nil
+	}
+	return s.save(filepath.Join(subscriptionsDir, strings.ToLower(r.SubscriptionID)+".json"), r)
+}
+
+// LoadSubscription loads the results of a subscription scan. Returns nil if the subscription was not checkpointed.
+func (s *Store) LoadSubscription(subscriptionID string) (*SubscriptionResult, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var r SubscriptionResult
+	found, err := s.load(filepath.Join(subscriptionsDir, strings.ToLower(subscriptionID)+".json"), &r)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Subscriptions loads the results of all checkpointed subscriptions
+func (s *Store) Subscriptions() ([]*SubscriptionResult, error) {
+	if s == nil {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(filepath.Join(s.dir, subscriptionsDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", s.dir, err)
+	}
+
+	results := []*SubscriptionResult{}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		var r SubscriptionResult
+		if _, err := s.load(filepath.Join(subscriptionsDir, e.Name()), &r); err != nil {
+			return nil, err
+		}
+		results = append(results, &r)
+	}
+	return results, nil
+}
+
+// IsCheckpoint returns true if dir contains a checkpoint
+func IsCheckpoint(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, manifestFile))
+	return err == nil && !info.IsDir()
+}
+
+// save writes v as JSON. The file is replaced atomically so a crash never leaves a truncated checkpoint.
+func (s *Store) save(name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint %s: %w", name, err)
+	}
+
+	path := filepath.Join(s.dir, name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write checkpoint %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *Store) load(name string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read checkpoint %s: %w", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("failed to parse checkpoint %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// HashFilters hashes the filters that change the checkpointed results: included and excluded
+// scopes and the naming and tag policies. Exceptions are applied after loading a checkpoint.
+func HashFilters(filters *models.Filters) string {
+	if filters == nil || filters.Azqr == nil {
+		return ""
+	}
+	data, err := json.Marshal(struct {
+		Include *models.IncludeFilter    `json:"include"`
+		Exclude *models.ExcludeFilter    `json:"exclude"`
+		Naming  *models.NamingConvention `json:"naming"`
+		Tags    *models.TagPolicy        `json:"tags"`
+	}{filters.Azqr.Include, filters.Azqr.Exclude, filters.Azqr.Naming, filters.Azqr.Tags})
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// normalize ignores the casing and order of the lists of a manifest
+func normalize(m Manifest) Manifest {
+	lower := func(values []string) []string {
+		r := []string{}
+		for _, v := range values {
+			r = append(r, strings.ToLower(v))
+		}
+		sort.Strings(r)
+		return r
+	}
+	clean := func(path string) string {
+		if path == "" {
+			return ""
+		}
+		return filepath.Clean(path)
+	}
+	packs := []string{}
+	for _, p := range m.RulePacks {
+		packs = append(packs, clean(p))
+	}
+	sort.Strings(packs)
+
+	return Manifest{
+		ManagementGroups: lower(m.ManagementGroups),
+		Subscriptions:    lower(m.Subscriptions),
+		ResourceGroups:   lower(m.ResourceGroups),
+		ScannerKeys:      lower(m.ScannerKeys),
+		Filters:          m.Filters,
+		Cloud:            strings.ToLower(m.Cloud),
+		Defender:         m.Defender,
+		Advisor:          m.Advisor,
+		Cost:             m.Cost,
+		Azqr:             m.Azqr,
+		Aprl:             m.Aprl,
+		RulesDir:         clean(m.RulesDir),
+		RulePacks:        packs,
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/Azure/azqr/internal/models"
+)
+
+const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+func newManifest() Manifest {
+	return Manifest{
+		Subscriptions: []string{subscriptionID, "11111111-1111-1111-1111-111111111111"},
+		ScannerKeys:   []string{"aks", "st"},
+		Filters:       HashFilters(models.NewFilters()),
+		Defender:      true,
+		Advisor:       true,
+		Azqr:          true,
+		Aprl:          true,
+	}
+}
+
+func newStore(t *testing.T, dir string, manifest Manifest, resume bool) *Store {
+	t.Helper()
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Open(manifest, resume); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	store := newStore(t, t.TempDir(), newManifest(), false)
+
+	var advisor []models.AdvisorResult
+	found, err := store.LoadPhase(PhaseAdvisor, &advisor)
+	if err != nil || found {
+		t.Fatalf("LoadPhase() = (%v, %v) before saving, want (false, nil)", found, err)
+	}
+
+	if err := store.SavePhase(PhaseAdvisor, []models.AdvisorResult{{SubscriptionID: subscriptionID, Name: "advice"}}); err != nil {
+		t.Fatal(err)
+	}
+	found, err = store.LoadPhase(PhaseAdvisor, &advisor)
+	if err != nil || !found || len(advisor) != 1 || advisor[0].Name != "advice" {
+		t.Fatalf("LoadPhase() = (%v, %v, %v), want the saved advisor results", found, err, advisor)
+	}
+
+	if err := store.SaveSubscription(&SubscriptionResult{SubscriptionID: subscriptionID, SubscriptionName: "prod"}); err != nil {
+		t.Fatal(err)
+	}
+	// subscription IDs are not case sensitive
+	r, err := store.LoadSubscription("00000000-0000-0000-0000-000000000000")
+	if err != nil || r == nil || r.SubscriptionName != "prod" {
+		t.Fatalf("LoadSubscription() = (%v, %v), want prod", r, err)
+	}
+	all, err := store.Subscriptions()
+	if err != nil || len(all) != 1 {
+		t.Fatalf("Subscriptions() = (%v, %v), want 1 subscription", all, err)
+	}
+
+	if !IsCheckpoint(store.Dir()) {
+		t.Error("IsCheckpoint() = false, want true")
+	}
+}
+
+func TestStore_Resume(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir, newManifest(), false)
+	if err := store.SavePhase(PhaseDefender, []models.DefenderResult{{SubscriptionID: subscriptionID}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveSubscription(&SubscriptionResult{SubscriptionID: subscriptionID}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the order and casing of the lists do not matter
+	manifest := newManifest()
+	manifest.Subscriptions = []string{"11111111-1111-1111-1111-111111111111", subscriptionID}
+	manifest.ScannerKeys = []string{"ST", "AKS"}
+	store = newStore(t, dir, manifest, true)
+
+	var defender []models.DefenderResult
+	if found, err := store.LoadPhase(PhaseDefender, &defender); err != nil || !found {
+		t.Errorf("LoadPhase() = (%v, %v) after resuming, want the saved results", found, err)
+	}
+	if r, err := store.LoadSubscription(subscriptionID); err != nil || r == nil {
+		t.Errorf("LoadSubscription() = (%v, %v) after resuming, want the saved results", r, err)
+	}
+}
+
+func TestStore_ResumeWithDifferentParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(m *Manifest)
+	}{
+		{"subscriptions", func(m *Manifest) { m.Subscriptions = []string{subscriptionID} }},
+		{"scanners", func(m *Manifest) { m.ScannerKeys = []string{"aks"} }},
+		{"filters", func(m *Manifest) {
+			filters := models.NewFilters()
+			filters.Azqr.Exclude.Services = []string{"/subscriptions/" + subscriptionID + "/resourceGroups/rg"}
+			m.Filters = HashFilters(filters)
+		}},
+		{"cloud", func(m *Manifest) { m.Cloud = "AzureChina" }},
+		{"defender", func(m *Manifest) { m.Defender = false }},
+		{"aprl", func(m *Manifest) { m.Aprl = false }},
+		{"rules", func(m *Manifest) { m.RulesDir = "rules" }},
+		{"rule packs", func(m *Manifest) { m.RulePacks = []string{"pack"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			newStore(t, dir, newManifest(), false)
+
+			manifest := newManifest()
+			tt.change(&manifest)
+			store, err := NewStore(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Open(manifest, true); err == nil {
+				t.Error("Open() resumed a checkpoint created with different parameters")
+			}
+		})
+	}
+}
+
+func TestStore_ReuseWithoutResume(t *testing.T) {
+	dir := t.TempDir()
+	store := newStore(t, dir, newManifest(), false)
+	if err := store.SavePhase(PhaseAprl, AprlResult{Results: []models.AprlResult{{RecommendationID: "stale"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveSubscription(&SubscriptionResult{SubscriptionID: subscriptionID}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a new scan in the same directory does not reuse the results of the previous one
+	store = newStore(t, dir, newManifest(), false)
+
+	var aprl AprlResult
+	if found, err := store.LoadPhase(PhaseAprl, &aprl); err != nil || found {
+		t.Errorf("LoadPhase() = (%v, %v), want the stale results removed", found, err)
+	}
+	if r, err := store.LoadSubscription(subscriptionID); err != nil || r != nil {
+		t.Errorf("LoadSubscription() = (%v, %v), want the stale results removed", r, err)
+	}
+}
+
+func TestStore_Nil(t *testing.T) {
+	var store *Store
+	if err := store.Open(newManifest(), true); err != nil {
+		t.Error(err)
+	}
+	if err := store.SavePhase(PhaseAdvisor, nil); err != nil {
+		t.Error(err)
+	}
+	var advisor []models.AdvisorResult
+	if found, err := store.LoadPhase(PhaseAdvisor, &advisor); err != nil || found {
+		t.Errorf("LoadPhase() = (%v, %v), want (false, nil)", found, err)
+	}
+	if r, err := store.LoadSubscription(subscriptionID); err != nil || r != nil {
+		t.Errorf("LoadSubscription() = (%v, %v), want (nil, nil)", r, err)
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+// Package compare computes the differences between two scans, read from
+// their JSON report files or from their checkpoint directories.
+package compare
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azqr/internal/checkpoint"
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/iancoleman/strcase"
+)
+
+type (
+	// Snapshot - Findings, inventory and costs of a scan
+	Snapshot struct {
+		Findings []models.Finding
+		// Resources by lower case resource ID
+		Resources map[string]renderers.CompareResource
+		// Costs by lower case service name
+		Costs map[string]Cost
+	}
+
+	// Cost - Cost of a service over all subscriptions
+	Cost struct {
+		ServiceName string
+		Currency    string
+		Value       float64
+	}
+
+	// record is a row of a JSON report file, keyed by the lower camel case column headers
+	record map[string]string
+)
+
+// tables are the JSON report files read from a scan, by extension
+var tables = []string{"impacted", "inventory", "advisor", "defenderRecommendations", "costs"}
+
+// LoadSnapshot loads a scan from a checkpoint directory, or from the JSON report files of a scan.
+// JSON report files are referenced by their output name, e.g. azqr_action_plan_2025_01_01_T000000,
+// or by any of the files, e.g. azqr_action_plan_2025_01_01_T000000.impacted.json
+// Checkpoints hold the raw scan results: the filters, the suppression tags and the exceptions are applied
+// to them as a scan does. JSON report files already reflect the filters of their scan.
+func LoadSnapshot(path string, filters *models.Filters) (*Snapshot, error) {
+	if checkpoint.IsCheckpoint(path) {
+		return loadCheckpoint(path, filters)
+	}
+	return loadJson(path)
+}
+
+func loadJson(path string) (*Snapshot, error) {
+	prefix := strings.TrimSuffix(path, ".json")
+	for _, t := range tables {
+		prefix = strings.TrimSuffix(prefix, "."+t)
+	}
+
+	records := map[string][]record{}
+	for _, t := range tables {
+		file := fmt.Sprintf("%s.%s.json", prefix, t)
+		data, err := os.ReadFile(file)
+		if errors.Is(err, os.ErrNotExist) && t != "impacted" && t != "inventory" {
+			// scans without advisor, defender or costs
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed reading scan results from file: %s: %w", file, err)
+		}
+
+		rows := []record{}
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, fmt.Errorf("failed parsing scan results from file: %s: %w", file, err)
+		}
+		records[t] = rows
+	}
+	return newSnapshot(records), nil
+}
+
+func loadCheckpoint(dir string, filters *models.Filters) (*Snapshot, error) {
+	store, err := checkpoint.NewStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// report files mask the subscription IDs, so checkpoints are masked as well to compare them with each other
+	data := renderers.NewReportData("", true)
+
+	var inventory checkpoint.ResourcesResult
+	if _, err := store.LoadPhase(checkpoint.PhaseResources, &inventory); err != nil {
+		return nil, err
+	}
+	for _, r := range inventory.Resources {
+		if !filters.Azqr.IsServiceExcluded(r.ID) {
+			data.Resources = append(data.Resources, r)
+		}
+	}
+
+	var aprl checkpoint.AprlResult
+	if _, err := store.LoadPhase(checkpoint.PhaseAprl, &aprl); err != nil {
+		return nil, err
+	}
+	for _, r := range aprl.Results {
+		if !filters.Azqr.IsServiceExcluded(r.ResourceID) && !filters.Azqr.IsRecommendationExcluded(r.RecommendationID) {
+			data.Aprl = append(data.Aprl, r)
+		}
+	}
+
+	var advisor []models.AdvisorResult
+	if _, err := store.LoadPhase(checkpoint.PhaseAdvisor, &advisor); err != nil {
+		return nil, err
+	}
+	for _, r := range advisor {
+		if !filters.Azqr.IsSubscriptionExcluded(r.SubscriptionID) && !filters.Azqr.IsServiceExcluded(r.ResourceID) {
+			data.Advisor = append(data.Advisor, r)
+		}
+	}
+
+	var defender []models.DefenderRecommendation
+	if _, err := store.LoadPhase(checkpoint.PhaseDefenderRecommendations, &defender); err != nil {
+		return nil, err
+	}
+	for _, r := range defender {
+		if !filters.Azqr.IsServiceExcluded(r.ResourceId) {
+			data.DefenderRecommendations = append(data.DefenderRecommendations, r)
+		}
+	}
+
+	subscriptions, err := store.Subscriptions()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range subscriptions {
+		if filters.Azqr.IsSubscriptionExcluded(s.SubscriptionID) {
+			continue
+		}
+		for _, d := range s.Azqr {
+			if filters.Azqr.IsServiceExcluded(d.ResourceID()) {
+				continue
+			}
+			for id, r := range d.Recommendations {
+				if filters.Azqr.IsRecommendationExcluded(r.RecommendationID) {
+					delete(d.Recommendations, id)
+				}
+			}
+			data.Azqr = append(data.Azqr, d)
+		}
+		if s.Cost != nil {
+			data.Cost.Items = append(data.Cost.Items, s.Cost.Items...)
+		}
+	}
+
+	// suppression tags and exceptions, as the scan applies them to the reports
+	resources := models.NewResourceIndex(inventory.Resources, inventory.Excluded)
+	data.Aprl, _ = models.SuppressAprlResults(data.Aprl, resources)
+	data.Aprl, _ = filters.Azqr.ApplyExceptions(data.Azqr, data.Aprl, time.Now().UTC())
+
+	return newSnapshot(map[string][]record{
+		"impacted":                toRecords(data.ImpactedTable()),
+		"inventory":               toRecords(data.ResourcesTable()),
+		"advisor":                 toRecords(data.AdvisorTable()),
+		"defenderRecommendations": toRecords(data.DefenderRecommendationsTable()),
+		"costs":                   toRecords(data.CostTable()),
+	}), nil
+}
+
+// newSnapshot creates a snapshot from the rows of the report tables
+func newSnapshot(records map[string][]record) *Snapshot {
+	s := &Snapshot{
+		Findings:  []models.Finding{},
+		Resources: map[string]renderers.CompareResource{},
+		Costs:     map[string]Cost{},
+	}
+
+	for _, r := range records["impacted"] {
+		// rules that failed to evaluate are not findings
+		if status := r.get("Status"); status != "" && status != string(models.StatusNotCompliant) {
+			continue
+		}
+		source := models.FindingSourceAzqr
+		if r.get("Validated Using") == "Azure Resource Graph" {
+			source = models.FindingSourceAprl
+		}
+		s.addFinding(source, r.get("Recommendation Id"), r.get("Recommendation"), r.get("Resource Id"))
+	}
+
+	for _, r := range records["advisor"] {
+		s.addFinding(models.FindingSourceAdvisor, r.get("Recommendation Id"), r.get("Description"), r.get("Resource Id"))
+	}
+
+	for _, r := range records["defenderRecommendations"] {
+		s.addFinding(models.FindingSourceDefender, r.get("Recommendation Name"), r.get("Recommendation Name"), r.get("Resource Id"))
+	}
+
+	for _, r := range records["inventory"] {
+		id := mask(r.get("Resource Id"))
+		s.Resources[strings.ToLower(id)] = renderers.CompareResource{
+			ResourceID:   id,
+			ResourceType: r.get("Resource Type"),
+			Name:         r.get("Resource Name"),
+			SLA:          r.get("SLA"),
+		}
+	}
+
+	for _, r := range records["costs"] {
+		name := r.get("Service Name")
+		value, err := strconv.ParseFloat(r.get("Value"), 64)
+		if err != nil {
+			continue
+		}
+		c := s.Costs[strings.ToLower(name)]
+		c.ServiceName = name
+		c.Currency = r.get("Currency")
+		c.Value += value
+		s.Costs[strings.ToLower(name)] = c
+	}
+	return s
+}
+
+func (s *Snapshot) addFinding(source models.FindingSource, recommendationID, recommendation, resourceID string) {
+	id := mask(resourceID)
+	s.Findings = append(s.Findings, models.NewFinding(source, recommendationID, recommendation, id, id))
+}
+
+// Compare returns the differences between the scans before and after
+func Compare(before, after *Snapshot) *renderers.CompareData {
+	findings := (&models.Baseline{Findings: before.Findings}).Compare(after.Findings)
+	data := &renderers.CompareData{
+		NewFindings:      sortFindings(findings.New),
+		ResolvedFindings: sortFindings(findings.Resolved),
+		AddedResources:   []renderers.CompareResource{},
+		RemovedResources: []renderers.CompareResource{},
+		SLAChanges:       []renderers.SLAChange{},
+		CostDeltas:       []renderers.CostDelta{},
+	}
+
+	for _, id := range sortedKeys(after.Resources) {
+		r := after.Resources[id]
+		b, ok := before.Resources[id]
+		if !ok {
+			data.AddedResources = append(data.AddedResources, r)
+		} else if b.SLA != r.SLA {
+			data.SLAChanges = append(data.SLAChanges, renderers.SLAChange{Resource: r, Before: b.SLA})
+		}
+	}
+	for _, id := range sortedKeys(before.Resources) {
+		if _, ok := after.Resources[id]; !ok {
+			data.RemovedResources = append(data.RemovedResources, before.Resources[id])
+		}
+	}
+
+	costs := map[string]renderers.CostDelta{}
+	for name, c := range after.Costs {
+		costs[name] = renderers.CostDelta{ServiceName: c.ServiceName, Currency: c.Currency, After: c.Value}
+	}
+	for name, c := range before.Costs {
+		delta, ok := costs[name]
+		if !ok {
+			delta = renderers.CostDelta{ServiceName: c.ServiceName, Currency: c.Currency}
+		}
+		delta.Before = c.Value
+		costs[name] = delta
+	}
+	for _, name := range sortedKeys(costs) {
+		data.CostDeltas = append(data.CostDeltas, costs[name])
+	}
+	return data
+}
+
+// get returns the value of a column by its header
+func (r record) get(header string) string {
+	return r[strcase.ToLowerCamel(header)]
+}
+
+// toRecords converts a report table to rows keyed like the JSON report files
+func toRecords(table [][]string) []record {
+	records := []record{}
+	headers := table[0]
+	for _, row := range table[1:] {
+		r := record{}
+		for i, value := range row {
+			r[strcase.ToLowerCamel(headers[i])] = value
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// mask masks the subscription ID of a resource ID, so masked and unmasked scans can be compared
+func mask(resourceID string) string {
+	if masked := renderers.MaskSubscriptionIDInResourceID(resourceID, true); masked != "" {
+		return masked
+	}
+	return resourceID
+}
+
+func sortFindings(findings []models.Finding) []models.Finding {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Source != findings[j].Source {
+			return findings[i].Source < findings[j].Source
+		}
+		if findings[i].RecommendationID != findings[j].RecommendationID {
+			return findings[i].RecommendationID < findings[j].RecommendationID
+		}
+		return findings[i].ResourceID < findings[j].ResourceID
+	})
+	return findings
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package compare
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azqr/internal/checkpoint"
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/renderers/json"
+	"github.com/Azure/azqr/internal/to"
+
+	// the filters include the resource types of the registered scanners
+	_ "github.com/Azure/azqr/internal/scanners/aks"
+	_ "github.com/Azure/azqr/internal/scanners/kv"
+	_ "github.com/Azure/azqr/internal/scanners/pip"
+	_ "github.com/Azure/azqr/internal/scanners/st"
+)
+
+const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+func TestCompare(t *testing.T) {
+	dir := t.TempDir()
+	aks := &models.Resource{ID: "/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks", SubscriptionID: subscriptionID, ResourceGroup: "rg", Type: "Microsoft.ContainerService/managedClusters", Name: "aks"}
+	st := &models.Resource{ID: "/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st", SubscriptionID: subscriptionID, ResourceGroup: "rg", Type: "Microsoft.Storage/storageAccounts", Name: "st"}
+	kv := &models.Resource{ID: "/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/kv", SubscriptionID: subscriptionID, ResourceGroup: "rg", Type: "Microsoft.KeyVault/vaults", Name: "kv"}
+
+	aksResult := func(sla string, recommendations ...string) models.AzqrServiceResult {
+		r := models.AzqrServiceResult{
+			SubscriptionID:  subscriptionID,
+			ResourceGroup:   "rg",
+			Type:            "Microsoft.ContainerService/managedClusters",
+			ServiceName:     "aks",
+			Recommendations: map[string]models.AzqrResult{"aks-003": {RecommendationID: "aks-003", RecommendationType: models.TypeSLA, Result: sla}},
+		}
+		for _, id := range recommendations {
+			r.Recommendations[id] = models.AzqrResult{RecommendationID: id, NotCompliant: true, Status: models.StatusNotCompliant}
+		}
+		return r
+	}
+
+	// the scan before is read from its masked JSON report files
+	before := renderers.NewReportData(filepath.Join(dir, "before"), true)
+	before.Resources = []*models.Resource{aks, st}
+	before.Azqr = []models.AzqrServiceResult{aksResult("99.9%", "aks-004", "aks-012")}
+	before.Cost.Items = []*models.CostResultItem{{SubscriptionID: subscriptionID, ServiceName: "Storage", Value: "10.5", Currency: "USD"}}
+	if err := json.CreateJsonReport(&before); err != nil {
+		t.Fatal(err)
+	}
+
+	// the scan after is read from its unmasked checkpoint
+	store, err := checkpoint.NewStore(filepath.Join(dir, "after"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Open(checkpoint.Manifest{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SavePhase(checkpoint.PhaseResources, checkpoint.ResourcesResult{Resources: []*models.Resource{aks, kv}}); err != nil {
+		t.Fatal(err)
+	}
+	err = store.SaveSubscription(&checkpoint.SubscriptionResult{
+		SubscriptionID: subscriptionID,
+		Azqr:           []models.AzqrServiceResult{aksResult("99.95%", "aks-004", "aks-001")},
+		Cost:           &models.CostResult{Items: []*models.CostResultItem{{SubscriptionID: subscriptionID, ServiceName: "Storage", Value: "12", Currency: "USD"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	beforeSnapshot, err := LoadSnapshot(filepath.Join(dir, "before.impacted.json"), newFilters(t, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	afterSnapshot, err := LoadSnapshot(filepath.Join(dir, "after"), newFilters(t, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := Compare(beforeSnapshot, afterSnapshot)
+
+	if len(data.NewFindings) != 1 || data.NewFindings[0].RecommendationID != "aks-001" {
+		t.Errorf("NewFindings = %v, want aks-001", data.NewFindings)
+	}
+	if len(data.ResolvedFindings) != 1 || data.ResolvedFindings[0].RecommendationID != "aks-012" {
+		t.Errorf("ResolvedFindings = %v, want aks-012", data.ResolvedFindings)
+	}
+	if len(data.AddedResources) != 1 || data.AddedResources[0].Name != "kv" {
+		t.Errorf("AddedResources = %v, want kv", data.AddedResources)
+	}
+	if len(data.RemovedResources) != 1 || data.RemovedResources[0].Name != "st" {
+		t.Errorf("RemovedResources = %v, want st", data.RemovedResources)
+	}
+	if len(data.SLAChanges) != 1 || data.SLAChanges[0].Before != "99.9%" || data.SLAChanges[0].Resource.SLA != "99.95%" {
+		t.Errorf("SLAChanges = %v, want aks from 99.9%% to 99.95%%", data.SLAChanges)
+	}
+	if len(data.CostDeltas) != 1 || data.CostDeltas[0].Before != 10.5 || data.CostDeltas[0].After != 12 {
+		t.Errorf("CostDeltas = %v, want Storage from 10.5 to 12", data.CostDeltas)
+	}
+}
+
+func newFilters(t *testing.T, content string) *models.Filters {
+	t.Helper()
+	file := ""
+	if content != "" {
+		file = filepath.Join(t.TempDir(), "filters.yaml")
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	filters, err := models.LoadFilters(file, []string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filters
+}
+
+// TestLoadSnapshot_Checkpoint loads the raw results of a checkpoint as the scan reports them
+func TestLoadSnapshot_Checkpoint(t *testing.T) {
+	const rg = "/subscriptions/" + subscriptionID + "/resourceGroups/rg"
+	aks := &models.Resource{ID: rg + "/providers/Microsoft.ContainerService/managedClusters/aks", SubscriptionID: subscriptionID, ResourceGroup: "rg", Type: "Microsoft.ContainerService/managedClusters", Name: "aks"}
+	st := &models.Resource{ID: rg + "/providers/Microsoft.Storage/storageAccounts/st", SubscriptionID: subscriptionID, ResourceGroup: "rg", Type: "Microsoft.Storage/storageAccounts", Name: "st"}
+	pip := &models.Resource{
+		ID: rg + "/providers/Microsoft.Network/publicIPAddresses/pip", SubscriptionID: subscriptionID, ResourceGroup: "rg", Type: "Microsoft.Network/publicIPAddresses", Name: "pip",
+		Tags: map[string]*string{models.SuppressTag: to.Ptr("pip-unused;reason=reserved")},
+	}
+
+	dir := filepath.Join(t.TempDir(), "checkpoint")
+	store, err := checkpoint.NewStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Open(checkpoint.Manifest{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SavePhase(checkpoint.PhaseResources, checkpoint.ResourcesResult{Resources: []*models.Resource{aks, st, pip}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SavePhase(checkpoint.PhaseAprl, checkpoint.AprlResult{Results: []models.AprlResult{
+		{RecommendationID: "pip-unused", ResourceID: pip.ID},
+		{RecommendationID: "st-aprl", ResourceID: st.ID},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	notCompliant := func(ids ...string) map[string]models.AzqrResult {
+		r := map[string]models.AzqrResult{}
+		for _, id := range ids {
+			r[id] = models.AzqrResult{RecommendationID: id, NotCompliant: true, Status: models.StatusNotCompliant}
+		}
+		return r
+	}
+	err = store.SaveSubscription(&checkpoint.SubscriptionResult{
+		SubscriptionID: subscriptionID,
+		Azqr: []models.AzqrServiceResult{
+			{SubscriptionID: subscriptionID, ResourceGroup: "rg", Type: aks.Type, ServiceName: "aks", Recommendations: notCompliant("aks-001", "aks-004", "aks-012")},
+			{SubscriptionID: subscriptionID, ResourceGroup: "rg", Type: st.Type, ServiceName: "st", Recommendations: notCompliant("st-001")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filters := newFilters(t, `
+azqr:
+  exclude:
+    services:
+      - `+st.ID+`
+    recommendations:
+      - aks-012
+  exceptions:
+    - resource: `+aks.ID+`
+      recommendation: aks-004
+      owner: secops@contoso.com
+      reason: accepted
+      expires: 2999-12-31
+`)
+	snapshot, err := LoadSnapshot(dir, filters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// st is excluded, aks-012 is excluded, aks-004 is excepted and pip-unused is suppressed
+	if len(snapshot.Findings) != 1 || snapshot.Findings[0].RecommendationID != "aks-001" {
+		t.Errorf("Findings = %v, want aks-001", snapshot.Findings)
+	}
+	if _, ok := snapshot.Resources[strings.ToLower(mask(st.ID))]; ok || len(snapshot.Resources) != 2 {
+		t.Errorf("Resources = %v, want aks and pip", snapshot.Resources)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+// Package gate evaluates the quality gate of a scan: conditions on the number
+// of AZQR and APRL findings that fail the scan when they are met.
+package gate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+)
+
+const (
+	// ExitOK is the exit code of a complete scan that met no quality gate condition
+	ExitOK = 0
+	// ExitScanError is the exit code of a scan that failed, was interrupted or left resources unassessed
+	ExitScanError = 1
+	// ExitThresholdExceeded is the exit code of a complete scan whose findings met a quality gate condition.
+	// It differs from 2, the exit code of a Go program that panics.
+	ExitThresholdExceeded = 3
+)
+
+type (
+	// Condition - Condition of the quality gate, e.g. impact=High,count>0.
+	// Filters with several values separated by | match any of them, e.g. impact=High|Medium.
+	Condition struct {
+		Expression      string
+		Impact          []string
+		Category        []string
+		Source          []string
+		Recommendations []string
+		// New only counts the findings that are not in the baseline
+		New bool
+		// Operator compares the number of findings to Threshold: >, >=, <, <=, = or !=
+		Operator  string
+		Threshold int
+	}
+
+	// Result - Number of findings matching a condition and whether the condition was met
+	Result struct {
+		Condition *Condition
+		Count     int
+		Failed    bool
+	}
+
+	finding struct {
+		source           models.FindingSource
+		category         string
+		impact           string
+		recommendationID string
+		resourceID       string
+	}
+)
+
+// operators are checked in order, so the two character operators come first
+var operators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// Parse parses a condition. Without a count comparison, the condition is met by any finding (count>0).
+func Parse(expression string) (*Condition, error) {
+	c := &Condition{Expression: expression, Operator: ">", Threshold: 0}
+	for _, term := range strings.Split(expression, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		if strings.HasPrefix(strings.ToLower(term), "count") {
+			if err := c.parseCount(strings.TrimSpace(term[len("count"):])); err != nil {
+				return nil, fmt.Errorf("invalid quality gate %s: %w", expression, err)
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(term, "=")
+		if !ok || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("invalid quality gate %s: %s is not a key=value filter", expression, term)
+		}
+		values := strings.Split(strings.TrimSpace(value), "|")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "impact":
+			c.Impact = values
+		case "category":
+			c.Category = values
+		case "source":
+			c.Source = values
+		case "recommendation":
+			c.Recommendations = values
+		case "new":
+			n, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid quality gate %s: new must be true or false", expression)
+			}
+			c.New = n
+		default:
+			return nil, fmt.Errorf("invalid quality gate %s: unknown filter %s, use impact, category, source, recommendation or new", expression, key)
+		}
+	}
+	return c, nil
+}
+
+func (c *Condition) parseCount(comparison string) error {
+	for _, op := range operators {
+		if !strings.HasPrefix(comparison, op) {
+			continue
+		}
+		threshold, err := strconv.Atoi(strings.TrimSpace(comparison[len(op):]))
+		if err != nil {
+			return fmt.Errorf("count must be compared to a number")
+		}
+		c.Operator = op
+		c.Threshold = threshold
+		return nil
+	}
+	return fmt.Errorf("count must be compared with %s", strings.Join(operators, ", "))
+}
+
+// Evaluate counts the AZQR and APRL findings matching every condition. Filters, suppressions and exceptions are already applied to the report data.
+func Evaluate(data *renderers.ReportData, conditions []*Condition) ([]Result, error) {
+	findings := []finding{}
+	for _, d := range data.Azqr {
+		for _, r := range d.Recommendations {
+			if r.NotCompliant {
+				findings = append(findings, finding{models.FindingSourceAzqr, string(r.Category), string(r.Impact), r.RecommendationID, d.ResourceID()})
+			}
+		}
+	}
+	for _, r := range data.Aprl {
+		findings = append(findings, finding{models.FindingSourceAprl, string(r.Category), string(r.Impact), r.RecommendationID, r.ResourceID})
+	}
+
+	isNew := map[string]bool{}
+	if data.Baseline != nil {
+		for _, f := range data.Baseline.New {
+			isNew[f.Fingerprint] = true
+		}
+	}
+
+	results := []Result{}
+	for _, c := range conditions {
+		if c.New && data.Baseline == nil {
+			return nil, fmt.Errorf("quality gate %s requires a baseline", c.Expression)
+		}
+
+		count := 0
+		for _, f := range findings {
+			if c.matches(f) && (!c.New || isNew[models.Fingerprint(f.recommendationID, f.resourceID)]) {
+				count++
+			}
+		}
+		results = append(results, Result{Condition: c, Count: count, Failed: c.compare(count)})
+	}
+	return results, nil
+}
+
+// ExitCode returns the exit code of a scan, with or without quality gate conditions. In order of precedence:
+// ExitScanError if the scan was interrupted, ExitThresholdExceeded if a condition was met by the findings collected,
+// even if scanners failed, and ExitScanError if scanners failed leaving resources unassessed.
+// Failures collecting costs, Advisor or Defender data do not change the exit code.
+func ExitCode(data *renderers.ReportData, results []Result) int {
+	if data.Incomplete {
+		return ExitScanError
+	}
+	if Failed(results) {
+		return ExitThresholdExceeded
+	}
+	for _, e := range data.Errors {
+		if e.Unassessed {
+			return ExitScanError
+		}
+	}
+	return ExitOK
+}
+
+// Failed returns true if any condition was met
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Failed {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Condition) matches(f finding) bool {
+	return matchesAny(c.Impact, f.impact) &&
+		matchesAny(c.Category, f.category) &&
+		matchesAny(c.Source, string(f.source)) &&
+		matchesAny(c.Recommendations, f.recommendationID)
+}
+
+func (c *Condition) compare(count int) bool {
+	switch c.Operator {
+	case ">=":
+		return count >= c.Threshold
+	case "<=":
+		return count <= c.Threshold
+	case "!=":
+		return count != c.Threshold
+	case "<":
+		return count < c.Threshold
+	case "=":
+		return count == c.Threshold
+	default:
+		return count > c.Threshold
+	}
+}
+
+// matchesAny returns true if there are no values or the value is one of them
+func matchesAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package gate
+
+import (
+	"testing"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+)
+
+const aksID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    bool
+		operator   string
+		threshold  int
+	}{
+		{"impact=High", false, ">", 0},
+		{"category=Security,count>=5", false, ">=", 5},
+		{"impact=High|Medium, count != 2", false, "!=", 2},
+		{"count=0", false, "=", 0},
+		{"new=true", false, ">", 0},
+		{"new=maybe", true, "", 0},
+		{"severity=High", true, "", 0},
+		{"impact", true, "", 0},
+		{"count>many", true, "", 0},
+		{"count~1", true, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			c, err := Parse(tt.expression)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c.Operator != tt.operator || c.Threshold != tt.threshold {
+				t.Errorf("Parse() = count%s%d, want count%s%d", c.Operator, c.Threshold, tt.operator, tt.threshold)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	data := renderers.NewReportData("", false)
+	data.Azqr = []models.AzqrServiceResult{
+		{
+			SubscriptionID: "sub",
+			ResourceGroup:  "rg",
+			Type:           "Microsoft.ContainerService/managedClusters",
+			ServiceName:    "aks",
+			Recommendations: map[string]models.AzqrResult{
+				"aks-001": {RecommendationID: "aks-001", Category: models.CategorySecurity, Impact: models.ImpactHigh, NotCompliant: true},
+				"aks-002": {RecommendationID: "aks-002", Category: models.CategorySecurity, Impact: models.ImpactMedium, NotCompliant: true},
+				"aks-003": {RecommendationID: "aks-003", Category: models.CategorySecurity, Impact: models.ImpactHigh},
+			},
+		},
+	}
+	data.Aprl = []models.AprlResult{
+		{RecommendationID: "aprl-1", ResourceID: aksID, Category: models.CategoryHighAvailability, Impact: models.ImpactHigh},
+	}
+
+	tests := []struct {
+		expression string
+		count      int
+		failed     bool
+	}{
+		{"impact=High", 2, true},
+		{"impact=high,source=APRL", 1, true},
+		{"category=Security", 2, true},
+		{"impact=High|Medium,count>3", 3, false},
+		{"recommendation=aks-003", 0, false},
+		{"count=0", 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			c, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			results, err := Evaluate(&data, []*Condition{c})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if results[0].Count != tt.count || results[0].Failed != tt.failed {
+				t.Errorf("Evaluate() = (%d, %v), want (%d, %v)", results[0].Count, results[0].Failed, tt.count, tt.failed)
+			}
+		})
+	}
+
+	// new findings require a baseline
+	c, _ := Parse("new=true,impact=High")
+	if _, err := Evaluate(&data, []*Condition{c}); err == nil {
+		t.Error("Evaluate() without a baseline should fail")
+	}
+
+	data.Baseline = &models.BaselineComparison{
+		New: []models.Finding{models.NewFinding(models.FindingSourceAprl, "aprl-1", "", aksID, aksID)},
+	}
+	results, err := Evaluate(&data, []*Condition{c})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Count != 1 || !Failed(results) {
+		t.Errorf("Evaluate() = %d new High impact findings, want 1", results[0].Count)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	met := []Result{{Count: 1, Failed: true}}
+	passed := []Result{{Count: 0}}
+	unassessed := []models.ScanError{{SubscriptionID: "sub", Scanner: "aks", Error: "forbidden", Unassessed: true}}
+	costs := []models.ScanError{{SubscriptionID: "sub", Scanner: "Costs", Error: "forbidden"}}
+
+	tests := []struct {
+		name       string
+		errors     []models.ScanError
+		incomplete bool
+		results    []Result
+		want       int
+	}{
+		{"passed", nil, false, passed, ExitOK},
+		{"without conditions", nil, false, nil, ExitOK},
+		{"threshold exceeded", nil, false, met, ExitThresholdExceeded},
+		{"unassessed resources", unassessed, false, passed, ExitScanError},
+		{"unassessed resources without conditions", unassessed, false, nil, ExitScanError},
+		{"unassessed resources and threshold exceeded", unassessed, false, met, ExitThresholdExceeded},
+		{"costs failed", costs, false, passed, ExitOK},
+		{"costs failed and threshold exceeded", costs, false, met, ExitThresholdExceeded},
+		{"incomplete", nil, true, passed, ExitScanError},
+		{"incomplete and threshold exceeded", nil, true, met, ExitScanError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := renderers.NewReportData("", false)
+			data.Errors = tt.errors
+			data.Incomplete = tt.incomplete
+			if got := ExitCode(&data, tt.results); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// the threshold code must not be mistaken for a Go panic, which exits with 2
+	if ExitThresholdExceeded == 2 || ExitThresholdExceeded == ExitScanError {
+		t.Errorf("ExitThresholdExceeded = %d collides with another exit code", ExitThresholdExceeded)
+	}
+}
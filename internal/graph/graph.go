@@ -4,26 +4,24 @@
 package graph
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/Azure/azqr/internal/az"
 	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/rs/zerolog/log"
 )
 
-// GraphQueryClient provides methods to query Azure Resource Graph using HTTP client.
+// GraphQueryClient provides methods to query Azure Resource Graph using the azcore pipeline.
 type GraphQueryClient struct {
-	httpClient  *http.Client // HTTP client for making requests
-	endpoint    string       // Resource Graph endpoint URL
-	accessToken string       // Bearer token for authentication
+	pipeline runtime.Pipeline // Pipeline with authentication, retry and telemetry policies
+	endpoint string           // Resource Graph endpoint URL
 }
 
 // GraphResult holds the data returned from a Resource Graph query.
@@ -53,34 +51,22 @@ type QueryResponse struct {
 	RetryAfter time.Duration // Value of x-ms-user-quota-resets-after header as timespan
 }
 
-// NewGraphQuery creates a new GraphQuery using the provided TokenCredential.
-func NewGraphQuery(cred azcore.TokenCredential) *GraphQueryClient {
-	// Create a new HTTP client with a timeout
-	httpClient := &http.Client{
-		Timeout: 60 * time.Second,
-	}
-
-	// Acquire an access token using the provided credential
-	token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{
-		Scopes: []string{"https://management.azure.com/.default"},
-	})
+// NewGraphQuery creates a new GraphQuery using the provided TokenCredential and ARM client options.
+func NewGraphQuery(cred azcore.TokenCredential, options *arm.ClientOptions) (*GraphQueryClient, error) {
+	client, err := az.NewClient(cred, options)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to acquire Azure access token")
+		return nil, fmt.Errorf("failed to create Resource Graph client: %w", err)
 	}
 
-	// Set the access token string
-	accessToken := token.Token
-
 	return &GraphQueryClient{
-		httpClient:  httpClient,
-		endpoint:    "https://management.azure.com/providers/Microsoft.ResourceGraph/resources?api-version=2021-03-01",
-		accessToken: accessToken,
-	}
+		pipeline: client.Pipeline(),
+		endpoint: runtime.JoinPaths(client.Endpoint(), "/providers/Microsoft.ResourceGraph/resources") + "?api-version=2021-03-01",
+	}, nil
 }
 
 // Query executes a Resource Graph query for the given subscriptions and query string.
 // It handles batching and pagination.
-func (q *GraphQueryClient) Query(ctx context.Context, query string, subscriptions []*string) *GraphResult {
+func (q *GraphQueryClient) Query(ctx context.Context, query string, subscriptions []*string) (*GraphResult, error) {
 	result := GraphResult{
 		Data: make([]interface{}, 0),
 	}
@@ -117,7 +103,7 @@ func (q *GraphQueryClient) Query(ctx context.Context, query string, subscription
 			}
 
 			startTime := time.Now()
-			resp, err := q.retry(ctx, 3, 10*time.Second, request)
+			resp, err := q.doRequest(ctx, request)
 			elapsed := time.Since(startTime).Milliseconds()
 			if err == nil {
 				result.Data = append(result.Data, resp.Data...)
@@ -127,91 +113,64 @@ func (q *GraphQueryClient) Query(ctx context.Context, query string, subscription
 				if skipToken != nil && elapsed < 333 {
 					// If the query took less than 333ms, wait to avoid throttling
 					log.Debug().Msgf("Graph query took %d ms, waiting to avoid throttling", elapsed)
-					time.Sleep(time.Duration(400-elapsed) * time.Millisecond)
+					if err := wait(ctx, time.Duration(400-elapsed)*time.Millisecond); err != nil {
+						return nil, err
+					}
 				}
 				// Quota limit reached, sleep for the duration specified in the response header
 				if resp.Quota == 0 {
 					duration := resp.RetryAfter
 					log.Debug().Msgf("Graph query quota limit reached. Sleeping for %s", duration)
-					time.Sleep(duration)
+					if err := wait(ctx, duration); err != nil {
+						return nil, err
+					}
 				}
 			} else {
-				log.Fatal().Err(err).Msgf("Failed to run Resource Graph query: %s", query)
-				return nil
+				return nil, fmt.Errorf("failed to run Resource Graph query: %s: %w", query, err)
 			}
 		}
 	}
-	return &result
+	return &result, nil
 }
 
-// retry executes the Resource Graph query with retries and exponential backoff.
-// Returns the QueryResponse or error.
-func (q *GraphQueryClient) retry(ctx context.Context, attempts int, sleep time.Duration, request QueryRequest) (*QueryResponse, error) {
-	var err error
-	for i := 0; ; i++ {
-		resp, err := q.doRequest(ctx, request)
-		if err == nil {
-			return resp, nil
-		}
-
-		errAsString := err.Error()
-
-		if i >= (attempts - 1) {
-			log.Info().Msgf("Retry limit reached. Error: %s", errAsString)
-			break
-		}
-
-		log.Debug().Msgf("Retrying after error: %s", errAsString)
-
-		time.Sleep(sleep)
-		sleep *= 2
+// wait pauses for the given duration or until the context is cancelled
+func wait(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
-	return nil, err
 }
 
-// doRequest sends the HTTP request to the Resource Graph API and returns the response.
+// doRequest sends the request to the Resource Graph API and returns the response.
+// Transient failures and throttled requests are retried by the pipeline.
 func (q *GraphQueryClient) doRequest(ctx context.Context, request QueryRequest) (*QueryResponse, error) {
-	// Serialize request to JSON
-	body, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, q.endpoint, bytes.NewReader(body))
+	req, err := runtime.NewRequest(ctx, http.MethodPost, q.endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+q.accessToken)
+	if err := runtime.MarshalAsJSON(req, request); err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
 
 	// Send request
-	resp, err := q.httpClient.Do(req)
+	resp, err := q.pipeline.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send HTTP request: %w", err)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Fatal().Err(err).Msg("Failed to close response body")
-		}
-	}()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
 
 	// Check for non-200 status codes
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("received non-2xx status code: %d, body: %s", resp.StatusCode, string(respBody))
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return nil, runtime.NewResponseError(resp)
 	}
 
 	// Parse response JSON
 	var queryResp QueryResponse
-	if err := json.Unmarshal(respBody, &queryResp); err != nil {
+	if err := runtime.UnmarshalAsJSON(resp, &queryResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
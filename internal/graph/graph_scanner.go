@@ -6,8 +6,10 @@ package graph
 import (
 	"context"
 	"embed"
+	"fmt"
 	"io/fs"
 	"math"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/Azure/azqr/internal/throttling"
 	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/rs/zerolog/log"
 	"gopkg.in/yaml.v3"
 )
@@ -34,6 +37,7 @@ type (
 		serviceScanners []models.IAzureScanner
 		filters         *models.Filters
 		subscriptions   map[string]string
+		rulePacks       []string
 	}
 
 	ScanType string
@@ -42,58 +46,100 @@ type (
 const (
 	AprlScanType   ScanType = "aprl/azure-resources"
 	OrphanScanType ScanType = "azure-orphan-resources"
+	// CustomScanType - Recommendations and KQL queries read from the rule pack directories
+	CustomScanType ScanType = "custom"
 	bucketCapacity          = 14
 	refillRate              = 12
 )
 
-// create a new APRL scanner
-func NewAprlScanner(serviceScanners []models.IAzureScanner, filters *models.Filters, subscriptions map[string]string) AprlScanner {
+// create a new APRL scanner. rulePacks are directories, e.g. git checkouts, with recommendations in the APRL format
+func NewAprlScanner(serviceScanners []models.IAzureScanner, filters *models.Filters, subscriptions map[string]string, rulePacks []string) AprlScanner {
+	scanType := []ScanType{
+		AprlScanType,
+		OrphanScanType,
+	}
+	if len(rulePacks) > 0 {
+		scanType = append(scanType, CustomScanType)
+	}
+
 	return AprlScanner{
-		scanType: []ScanType{
-			AprlScanType,
-			OrphanScanType,
-		},
+		scanType:        scanType,
 		serviceScanners: serviceScanners,
 		filters:         filters,
 		subscriptions:   subscriptions,
+		rulePacks:       rulePacks,
 	}
 }
 
-// GetAprlRecommendations returns a map with all APRL recommendations
+// GetAprlRecommendations returns a map with all APRL recommendations.
+// Rule pack recommendations replace embedded recommendations with the same id, e.g. when a pack is a newer APRL release,
+// and keep their source.
 func (a AprlScanner) GetAprlRecommendations() map[string]map[string]models.AprlRecommendation {
 	recommendations := map[string]map[string]models.AprlRecommendation{}
 	for _, t := range a.scanType {
 		source := "APRL"
-		if t == OrphanScanType {
+		switch t {
+		case OrphanScanType:
 			source = "AOR"
+		case CustomScanType:
+			source = "RulePack"
 		}
-		rs := a.getAprlRecommendations(string(t))
-		for t, r := range rs {
-			for _, r := range r {
-				if recommendations[t] == nil {
-					recommendations[t] = map[string]models.AprlRecommendation{}
+
+		for _, rs := range a.getRecommendations(t) {
+			for t, r := range rs {
+				for _, r := range r {
+					if recommendations[t] == nil {
+						recommendations[t] = map[string]models.AprlRecommendation{}
+					}
+					r.Source = source
+					if existing, ok := recommendations[t][r.RecommendationID]; ok {
+						r.Source = existing.Source
+					}
+					recommendations[t][r.RecommendationID] = r
 				}
-				r.Source = source
-				recommendations[t][r.RecommendationID] = r
 			}
 		}
 	}
 	return recommendations
 }
 
-func (a AprlScanner) getAprlRecommendations(path string) map[string]map[string]models.AprlRecommendation {
-	r := map[string]map[string]models.AprlRecommendation{}
+// getRecommendations returns the recommendations of a scan type, per file system
+func (a AprlScanner) getRecommendations(t ScanType) []map[string]map[string]models.AprlRecommendation {
+	if t != CustomScanType {
+		fsys, err := fs.Sub(embededFiles, string(t))
+		if err != nil {
+			return nil
+		}
+		r, err := getAprlRecommendations(fsys)
+		if err != nil {
+			return nil
+		}
+		return []map[string]map[string]models.AprlRecommendation{r}
+	}
 
-	fsys, err := fs.Sub(embededFiles, path)
-	if err != nil {
-		return nil
+	rs := []map[string]map[string]models.AprlRecommendation{}
+	for _, dir := range a.rulePacks {
+		r, err := getAprlRecommendations(os.DirFS(dir))
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to load rule pack %s. Skipping...", dir)
+			continue
+		}
+		rs = append(rs, r)
 	}
+	return rs
+}
+
+func getAprlRecommendations(fsys fs.FS) (map[string]map[string]models.AprlRecommendation, error) {
+	r := map[string]map[string]models.AprlRecommendation{}
 
 	q := map[string]string{}
-	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
+		if isHiddenDir(path, d) {
+			return fs.SkipDir
+		}
 		if !d.IsDir() && strings.HasSuffix(path, ".kql") {
 			content, err := fs.ReadFile(fsys, path)
 			if err != nil {
@@ -106,26 +152,44 @@ func (a AprlScanner) getAprlRecommendations(path string) map[string]map[string]m
 		return nil
 	})
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
 	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if !d.IsDir() && strings.HasSuffix(path, ".yaml") {
+		if isHiddenDir(path, d) {
+			return fs.SkipDir
+		}
+		if !d.IsDir() && (strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
 			content, err := fs.ReadFile(fsys, path)
 			if err != nil {
 				return err
 			}
 
+			var node yaml.Node
+			if err := yaml.Unmarshal(content, &node); err != nil {
+				log.Warn().Err(err).Msgf("Skipping %s: invalid YAML", path)
+				return nil
+			}
+			if len(node.Content) == 0 || node.Content[0].Kind != yaml.SequenceNode {
+				// checkouts may contain other YAML files, e.g. pipelines
+				log.Debug().Msgf("Skipping %s: not a list of recommendations", path)
+				return nil
+			}
+
 			var recommendations []models.AprlRecommendation
-			err = yaml.Unmarshal(content, &recommendations)
-			if err != nil {
-				return err
+			if err := node.Decode(&recommendations); err != nil {
+				log.Warn().Err(err).Msgf("Skipping %s: invalid recommendations", path)
+				return nil
 			}
 
 			for _, recommendation := range recommendations {
+				if recommendation.RecommendationID == "" || recommendation.ResourceType == "" {
+					continue
+				}
+
 				t := strings.ToLower(recommendation.ResourceType)
 				if _, ok := r[t]; !ok {
 					r[t] = map[string]models.AprlRecommendation{}
@@ -142,10 +206,15 @@ func (a AprlScanner) getAprlRecommendations(path string) map[string]map[string]m
 		return nil
 	})
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	return r
+	return r, nil
+}
+
+// isHiddenDir returns true for directories such as .git and .github
+func isHiddenDir(path string, d fs.DirEntry) bool {
+	return d.IsDir() && path != "." && strings.HasPrefix(d.Name(), ".")
 }
 
 func (a AprlScanner) ListRecommendations() (map[string]map[string]models.AprlRecommendation, []models.AprlRecommendation) {
@@ -173,10 +242,14 @@ func (a AprlScanner) ListRecommendations() (map[string]map[string]models.AprlRec
 	return recommendations, rules
 }
 
-// AprlScan scans Azure resources using Azure Proactive Resiliency Library v2 (APRL)
-func (a AprlScanner) Scan(ctx context.Context, cred azcore.TokenCredential) []models.AprlResult {
+// AprlScan scans Azure resources using Azure Proactive Resiliency Library v2 (APRL).
+// Rules that fail are reported as scan errors and do not stop the scan.
+func (a AprlScanner) Scan(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions) ([]models.AprlResult, []models.ScanError, error) {
 	results := []models.AprlResult{}
-	graph := NewGraphQuery(cred)
+	graph, err := NewGraphQuery(cred, options)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	_, rules := a.ListRecommendations()
 
@@ -189,11 +262,11 @@ func (a AprlScanner) Scan(ctx context.Context, cred azcore.TokenCredential) []mo
 
 	// Buffer the jobs and results channels to the number of rules to avoid deadlocks.
 	jobs := make(chan models.AprlRecommendation, len(rules))
-	ch := make(chan []models.AprlResult, len(rules))
+	ch := make(chan aprlJobResult, len(rules))
 
 	// Create a burst limiter to control the rate of requests
 	limiter := throttling.NewLimiter(bucketCapacity, refillRate, 5*time.Second, 200*time.Millisecond)
-	burstLimiter := limiter.Start()
+	burstLimiter := limiter.Start(ctx)
 
 	var wg sync.WaitGroup
 
@@ -220,9 +293,19 @@ func (a AprlScanner) Scan(ctx context.Context, cred azcore.TokenCredential) []mo
 	wg.Wait()
 
 	// Receive results from workers
+	scanErrors := []models.ScanError{}
 	for i := 0; i < len(rules); i++ {
 		res := <-ch
-		for _, r := range res {
+		if res.err != nil {
+			// rules skipped because the scan was interrupted are not failures
+			if ctx.Err() == nil {
+				scanError := models.NewScanError("", "", res.rule.Source, res.rule.ResourceType, res.err)
+				scanError.Unassessed = true
+				scanErrors = append(scanErrors, scanError)
+			}
+			continue
+		}
+		for _, r := range res.results {
 			if a.filters.Azqr.IsServiceExcluded(r.ResourceID) {
 				continue
 			}
@@ -230,19 +313,35 @@ func (a AprlScanner) Scan(ctx context.Context, cred azcore.TokenCredential) []mo
 		}
 	}
 
-	return results
+	return results, scanErrors, nil
+}
+
+// aprlJobResult holds the outcome of a single APRL recommendation query.
+type aprlJobResult struct {
+	rule    models.AprlRecommendation
+	results []models.AprlResult
+	err     error
 }
 
-func (a *AprlScanner) worker(ctx context.Context, graph *GraphQueryClient, subscriptions map[string]string, jobs <-chan models.AprlRecommendation, results chan<- []models.AprlResult, wg *sync.WaitGroup, burstLimiter <-chan struct{}) {
+func (a *AprlScanner) worker(ctx context.Context, graph *GraphQueryClient, subscriptions map[string]string, jobs <-chan models.AprlRecommendation, results chan<- aprlJobResult, wg *sync.WaitGroup, burstLimiter <-chan struct{}) {
 	// worker processes batches of APRL recommendations from the jobs channel
 	for r := range jobs {
-		<-burstLimiter // Wait for a token from the burstLimiter channel before starting the
+		// Wait for a token from the burstLimiter channel before starting the scan
+		select {
+		case <-burstLimiter:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			results <- aprlJobResult{rule: r, err: ctx.Err()}
+			wg.Done()
+			continue
+		}
 		models.LogGraphRecommendationScan(r.ResourceType, r.RecommendationID)
 		res, err := a.graphScan(ctx, graph, r, subscriptions)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to scan")
+			err = fmt.Errorf("failed to scan using rule %s: %w", r.RecommendationID, err)
 		}
-		results <- res
+		results <- aprlJobResult{rule: r, results: res, err: err}
 		wg.Done()
 	}
 }
@@ -255,7 +354,10 @@ func (a AprlScanner) graphScan(ctx context.Context, graphClient *GraphQueryClien
 	}
 
 	if rule.GraphQuery != "" {
-		result := graphClient.Query(ctx, rule.GraphQuery, subs)
+		result, err := graphClient.Query(ctx, rule.GraphQuery, subs)
+		if err != nil {
+			return nil, err
+		}
 		if result.Data != nil {
 			for _, row := range result.Data {
 				m := row.(map[string]interface{})
@@ -0,0 +1,69 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAprlScanner_RulePacks(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"azure-resources/Storage/storageAccounts/recommendations.yaml": `
+- aprlGuid: 00000000-0000-0000-0000-0000000000aa
+  recommendationTypeId: null
+  recommendationMetadataState: Active
+  description: Storage accounts must use our naming convention
+  recommendationControl: Governance
+  recommendationImpact: Low
+  recommendationResourceType: Microsoft.Storage/storageAccounts
+  automationAvailable: true
+`,
+		"azure-resources/Storage/storageAccounts/kql/00000000-0000-0000-0000-0000000000aa.kql": "resources | where type =~ 'microsoft.storage/storageaccounts'",
+		// replaces the embedded orphan resources recommendation
+		"azure-resources/Network/publicIPAddresses/recommendations.yaml": `
+- aprlGuid: 5e6f7a8b-9c0d-1e2f-3a4b-5c6d7e8f9a0b
+  recommendationTypeId: null
+  recommendationMetadataState: Active
+  description: Public IPs not attached to any resource, newer release
+  recommendationControl: Governance
+  recommendationImpact: Medium
+  recommendationResourceType: Microsoft.Network/publicIPAddresses
+  automationAvailable: true
+`,
+		".github/workflows/ci.yml":    "on: push\njobs: {}\n",
+		"docs/config.yaml":            "title: not a recommendation\n",
+		"broken/recommendations.yaml": "- aprlGuid: [\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanner := NewAprlScanner(nil, nil, nil, []string{dir})
+	recommendations := scanner.GetAprlRecommendations()
+
+	r, ok := recommendations["microsoft.storage/storageaccounts"]["00000000-0000-0000-0000-0000000000aa"]
+	if !ok {
+		t.Fatal("rule pack recommendation was not loaded")
+	}
+	if r.Source != "RulePack" {
+		t.Errorf("Source = %s, want RulePack", r.Source)
+	}
+	if r.GraphQuery != files["azure-resources/Storage/storageAccounts/kql/00000000-0000-0000-0000-0000000000aa.kql"] {
+		t.Errorf("GraphQuery = %s, want the query of the kql file", r.GraphQuery)
+	}
+
+	r = recommendations["microsoft.network/publicipaddresses"]["5e6f7a8b-9c0d-1e2f-3a4b-5c6d7e8f9a0b"]
+	if r.Recommendation != "Public IPs not attached to any resource, newer release" || r.Source != "AOR" {
+		t.Errorf("recommendation = %s from %s, want the rule pack recommendation with the AOR source", r.Recommendation, r.Source)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+type (
+	// Finding - Not compliant recommendation for a resource, identified by a fingerprint stable across scans
+	Finding struct {
+		Fingerprint      string        `json:"fingerprint"`
+		Source           FindingSource `json:"source"`
+		RecommendationID string        `json:"recommendationId"`
+		Recommendation   string        `json:"recommendation"`
+		ResourceID       string        `json:"resourceId"`
+	}
+
+	// FindingSource - Scanner that reported a finding
+	FindingSource string
+
+	// Baseline - Findings accepted at the time the baseline was written
+	Baseline struct {
+		Created  time.Time `json:"created"`
+		Findings []Finding `json:"findings"`
+	}
+
+	// BaselineComparison - Findings of a scan split by their presence in a baseline
+	BaselineComparison struct {
+		// New findings are not in the baseline
+		New []Finding
+		// Existing findings are in the baseline
+		Existing []Finding
+		// Resolved findings are in the baseline but were not found anymore
+		Resolved []Finding
+	}
+)
+
+const (
+	FindingSourceAzqr     FindingSource = "AZQR"
+	FindingSourceAprl     FindingSource = "APRL"
+	FindingSourceAdvisor  FindingSource = "Advisor"
+	FindingSourceDefender FindingSource = "Defender"
+)
+
+// NewFinding creates a finding fingerprinted by the recommendation ID and the normalised resource ID.
+// displayResourceID is the resource ID shown in reports and baselines, e.g. with a masked subscription ID.
+func NewFinding(source FindingSource, recommendationID, recommendation, resourceID, displayResourceID string) Finding {
+	return Finding{
+		Fingerprint:      Fingerprint(recommendationID, resourceID),
+		Source:           source,
+		RecommendationID: recommendationID,
+		Recommendation:   recommendation,
+		ResourceID:       displayResourceID,
+	}
+}
+
+// Fingerprint returns a stable identifier of a recommendation for a resource.
+// Resource IDs are compared case insensitively and without trailing slashes.
+func Fingerprint(recommendationID, resourceID string) string {
+	id := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(resourceID), "/"))
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(recommendationID)) + "|" + id))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadBaseline reads a baseline written with WriteBaseline
+func LoadBaseline(file string) (*Baseline, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading baseline from file: %s: %w", file, err)
+	}
+
+	baseline := &Baseline{}
+	if err := json.Unmarshal(data, baseline); err != nil {
+		return nil, fmt.Errorf("failed parsing baseline from file: %s: %w", file, err)
+	}
+	return baseline, nil
+}
+
+// WriteBaseline writes the findings to a baseline file
+func WriteBaseline(file string, findings []Finding) error {
+	baseline := Baseline{
+		Created:  time.Now().UTC(),
+		Findings: findings,
+	}
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+
+	if err := os.WriteFile(file, data, 0o644); err != nil {
+		return fmt.Errorf("failed writing baseline to file: %s: %w", file, err)
+	}
+	return nil
+}
+
+// Compare splits the findings of a scan into new and existing ones, and returns the findings of the baseline that were resolved
+func (b *Baseline) Compare(findings []Finding) *BaselineComparison {
+	accepted := map[string]bool{}
+	for _, f := range b.Findings {
+		accepted[f.Fingerprint] = true
+	}
+
+	comparison := &BaselineComparison{
+		New:      []Finding{},
+		Existing: []Finding{},
+		Resolved: []Finding{},
+	}
+	found := map[string]bool{}
+	for _, f := range findings {
+		found[f.Fingerprint] = true
+		if accepted[f.Fingerprint] {
+			comparison.Existing = append(comparison.Existing, f)
+		} else {
+			comparison.New = append(comparison.New, f)
+		}
+	}
+
+	for _, f := range b.Findings {
+		if !found[f.Fingerprint] {
+			comparison.Resolved = append(comparison.Resolved, f)
+			// a finding listed twice in the baseline is resolved once
+			found[f.Fingerprint] = true
+		}
+	}
+	return comparison
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBaseline_Compare(t *testing.T) {
+	const id = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks"
+	file := filepath.Join(t.TempDir(), "baseline.json")
+	err := WriteBaseline(file, []Finding{
+		NewFinding(FindingSourceAzqr, "aks-004", "", id, id),
+		NewFinding(FindingSourceAzqr, "aks-012", "", id, id),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseline, err := LoadBaseline(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// resource IDs are normalised, so a finding is found again regardless of the casing
+	comparison := baseline.Compare([]Finding{
+		NewFinding(FindingSourceAzqr, "AKS-004", "", "/subscriptions/sub/resourcegroups/RG/providers/Microsoft.ContainerService/managedClusters/aks/", id),
+		NewFinding(FindingSourceAzqr, "aks-001", "", id, id),
+	})
+
+	if len(comparison.New) != 1 || comparison.New[0].RecommendationID != "aks-001" {
+		t.Errorf("New = %v, want aks-001", comparison.New)
+	}
+	if len(comparison.Existing) != 1 || comparison.Existing[0].RecommendationID != "AKS-004" {
+		t.Errorf("Existing = %v, want AKS-004", comparison.Existing)
+	}
+	if len(comparison.Resolved) != 1 || comparison.Resolved[0].RecommendationID != "aks-012" {
+		t.Errorf("Resolved = %v, want aks-012", comparison.Resolved)
+	}
+}
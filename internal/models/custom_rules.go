@@ -0,0 +1,361 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tidwall/gjson"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// CustomRules - Declarative rules loaded from YAML, per lower case resource type and recommendation id
+	CustomRules map[string]map[string]AzqrRecommendation
+
+	// CustomRule - Declarative rule evaluated over the ARM JSON of a resource
+	CustomRule struct {
+		RecommendationID string `yaml:"recommendationId"`
+		ResourceType     string `yaml:"resourceType"`
+		Recommendation   string `yaml:"recommendation"`
+		Category         string `yaml:"category"`
+		Impact           string `yaml:"impact"`
+		LearnMoreUrl     string `yaml:"learnMoreUrl"`
+		// Result is an optional GJSON path whose value is reported as the result of the rule
+		Result string `yaml:"result"`
+		// NotCompliantWhen is the condition under which the resource does not comply with the rule
+		NotCompliantWhen *Condition `yaml:"notCompliantWhen"`
+	}
+
+	// Condition - Azure Policy like condition over the ARM JSON of a resource.
+	// Field is a GJSON path (https://github.com/tidwall/gjson/blob/master/SYNTAX.md), e.g. properties.agentPoolProfiles.#
+	Condition struct {
+		AllOf []Condition `yaml:"allOf"`
+		AnyOf []Condition `yaml:"anyOf"`
+		Not   *Condition  `yaml:"not"`
+
+		Field           string        `yaml:"field"`
+		Exists          *bool         `yaml:"exists"`
+		Equals          interface{}   `yaml:"equals"`
+		NotEquals       interface{}   `yaml:"notEquals"`
+		In              []interface{} `yaml:"in"`
+		NotIn           []interface{} `yaml:"notIn"`
+		Contains        *string       `yaml:"contains"`
+		NotContains     *string       `yaml:"notContains"`
+		Greater         *float64      `yaml:"greater"`
+		GreaterOrEquals *float64      `yaml:"greaterOrEquals"`
+		Less            *float64      `yaml:"less"`
+		LessOrEquals    *float64      `yaml:"lessOrEquals"`
+	}
+)
+
+// LoadCustomRules loads the declarative rules of every .yaml and .yml file in dir and its subdirectories
+// Custom rules cannot reuse the id of a built-in recommendation, which they would silently replace,
+// and must target a resource type of a registered scanner, as other rules would never run.
+func LoadCustomRules(dir string) (CustomRules, error) {
+	rules := CustomRules{}
+	builtIn := builtInRecommendationIDs()
+	resourceTypes := scannedResourceTypes()
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if d.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var definitions []CustomRule
+		if err := yaml.Unmarshal(content, &definitions); err != nil {
+			return fmt.Errorf("failed to parse custom rules %s: %w", path, err)
+		}
+
+		for _, definition := range definitions {
+			if err := definition.validate(); err != nil {
+				return fmt.Errorf("invalid custom rule in %s: %w", path, err)
+			}
+			if builtIn[strings.ToLower(definition.RecommendationID)] {
+				return fmt.Errorf("custom rule %s in %s has the id of a built-in recommendation", definition.RecommendationID, path)
+			}
+			t := strings.ToLower(definition.ResourceType)
+			if !resourceTypes[t] {
+				return fmt.Errorf("custom rule %s in %s targets %s, which is not scanned by azqr", definition.RecommendationID, path, definition.ResourceType)
+			}
+			if rules[t] == nil {
+				rules[t] = map[string]AzqrRecommendation{}
+			}
+			if _, exists := rules[t][definition.RecommendationID]; exists {
+				return fmt.Errorf("duplicate custom rule %s in %s", definition.RecommendationID, path)
+			}
+			rules[t][definition.RecommendationID] = definition.toAzqrRecommendation()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load custom rules from %s: %w", dir, err)
+	}
+
+	log.Info().Msgf("Loaded %d custom rules from %s", rules.count(), dir)
+	return rules, nil
+}
+
+// ForResourceTypes returns the custom rules of the given resource types
+func (c CustomRules) ForResourceTypes(resourceTypes []string) map[string]AzqrRecommendation {
+	rules := map[string]AzqrRecommendation{}
+	for _, t := range resourceTypes {
+		for id, r := range c[strings.ToLower(t)] {
+			rules[id] = r
+		}
+	}
+	return rules
+}
+
+// builtInRecommendationIDs returns the lower case ids of the recommendations of the registered scanners
+func builtInRecommendationIDs() map[string]bool {
+	ids := map[string]bool{}
+	for _, scanners := range ScannerList {
+		for _, s := range scanners {
+			for id := range s.GetRecommendations() {
+				ids[strings.ToLower(id)] = true
+			}
+		}
+	}
+	return ids
+}
+
+// scannedResourceTypes returns the lower case resource types of the registered scanners
+func scannedResourceTypes() map[string]bool {
+	types := map[string]bool{}
+	for _, scanners := range ScannerList {
+		for _, s := range scanners {
+			for _, t := range s.ResourceTypes() {
+				types[strings.ToLower(t)] = true
+			}
+		}
+	}
+	return types
+}
+
+func (c CustomRules) count() int {
+	n := 0
+	for _, rules := range c {
+		n += len(rules)
+	}
+	return n
+}
+
+func (r CustomRule) validate() error {
+	switch {
+	case r.RecommendationID == "":
+		return errors.New("recommendationId is required")
+	case r.ResourceType == "":
+		return fmt.Errorf("%s: resourceType is required", r.RecommendationID)
+	case r.Recommendation == "":
+		return fmt.Errorf("%s: recommendation is required", r.RecommendationID)
+	case r.NotCompliantWhen == nil:
+		return fmt.Errorf("%s: notCompliantWhen is required", r.RecommendationID)
+	}
+
+	switch RecommendationImpact(r.Impact) {
+	case ImpactHigh, ImpactMedium, ImpactLow:
+	default:
+		return fmt.Errorf("%s: impact must be High, Medium or Low", r.RecommendationID)
+	}
+
+	if err := r.NotCompliantWhen.validate(); err != nil {
+		return fmt.Errorf("%s: %w", r.RecommendationID, err)
+	}
+	return nil
+}
+
+func (r CustomRule) toAzqrRecommendation() AzqrRecommendation {
+	rule := r
+	return AzqrRecommendation{
+		RecommendationID: r.RecommendationID,
+		ResourceType:     r.ResourceType,
+		Recommendation:   r.Recommendation,
+		Category:         RecommendationCategory(r.Category),
+		Impact:           RecommendationImpact(r.Impact),
+		LearnMoreUrl:     r.LearnMoreUrl,
+		Source:           "Custom",
+		Eval: func(target interface{}, scanContext *ScanContext) (bool, string) {
+			doc, err := scanContext.targetJSON(target)
+			if err != nil {
+				log.Warn().Err(err).Msgf("Failed to evaluate custom rule %s", rule.RecommendationID)
+				return false, ""
+			}
+
+			broken := rule.NotCompliantWhen.evaluate(doc)
+			result := ""
+			if rule.Result != "" {
+				result = gjson.GetBytes(doc, rule.Result).String()
+			}
+			return broken, result
+		},
+		Evidence: r.NotCompliantWhen.fields(),
+	}
+}
+
+func (c *Condition) validate() error {
+	logical := 0
+	if len(c.AllOf) > 0 {
+		logical++
+	}
+	if len(c.AnyOf) > 0 {
+		logical++
+	}
+	if c.Not != nil {
+		logical++
+	}
+
+	operators := c.operators()
+	switch {
+	case logical+operators == 0:
+		return errors.New("condition must have allOf, anyOf, not or a field operator")
+	case logical > 1 || (logical > 0 && (c.Field != "" || operators > 0)):
+		return errors.New("condition must have exactly one of allOf, anyOf, not or a field operator")
+	case operators > 1:
+		return fmt.Errorf("condition on %s must have exactly one operator", c.Field)
+	case operators == 1 && c.Field == "":
+		return errors.New("field is required")
+	}
+
+	for _, children := range [][]Condition{c.AllOf, c.AnyOf} {
+		for i := range children {
+			if err := children[i].validate(); err != nil {
+				return err
+			}
+		}
+	}
+	if c.Not != nil {
+		return c.Not.validate()
+	}
+	return nil
+}
+
+// fields returns the distinct fields of the condition, which are captured as evidence
+func (c *Condition) fields() []string {
+	fields := []string{}
+	seen := map[string]bool{}
+	var walk func(c *Condition)
+	walk = func(c *Condition) {
+		if c == nil {
+			return
+		}
+		if c.Field != "" && !seen[c.Field] {
+			seen[c.Field] = true
+			fields = append(fields, c.Field)
+		}
+		for i := range c.AllOf {
+			walk(&c.AllOf[i])
+		}
+		for i := range c.AnyOf {
+			walk(&c.AnyOf[i])
+		}
+		walk(c.Not)
+	}
+	walk(c)
+	return fields
+}
+
+func (c *Condition) operators() int {
+	n := 0
+	for _, set := range []bool{
+		c.Exists != nil, c.Equals != nil, c.NotEquals != nil, c.In != nil, c.NotIn != nil,
+		c.Contains != nil, c.NotContains != nil,
+		c.Greater != nil, c.GreaterOrEquals != nil, c.Less != nil, c.LessOrEquals != nil,
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// evaluate returns true if the condition holds for the ARM JSON document
+func (c *Condition) evaluate(doc []byte) bool {
+	switch {
+	case len(c.AllOf) > 0:
+		for i := range c.AllOf {
+			if !c.AllOf[i].evaluate(doc) {
+				return false
+			}
+		}
+		return true
+	case len(c.AnyOf) > 0:
+		for i := range c.AnyOf {
+			if c.AnyOf[i].evaluate(doc) {
+				return true
+			}
+		}
+		return false
+	case c.Not != nil:
+		return !c.Not.evaluate(doc)
+	}
+
+	value := gjson.GetBytes(doc, c.Field)
+	exists := value.Exists() && value.Type != gjson.Null
+
+	switch {
+	case c.Exists != nil:
+		return exists == *c.Exists
+	case c.Equals != nil:
+		return equals(value, c.Equals)
+	case c.NotEquals != nil:
+		return !equals(value, c.NotEquals)
+	case c.In != nil:
+		return in(value, c.In)
+	case c.NotIn != nil:
+		return !in(value, c.NotIn)
+	case c.Contains != nil:
+		return exists && strings.Contains(strings.ToLower(value.String()), strings.ToLower(*c.Contains))
+	case c.NotContains != nil:
+		return !exists || !strings.Contains(strings.ToLower(value.String()), strings.ToLower(*c.NotContains))
+	case c.Greater != nil:
+		return exists && value.Float() > *c.Greater
+	case c.GreaterOrEquals != nil:
+		return exists && value.Float() >= *c.GreaterOrEquals
+	case c.Less != nil:
+		return exists && value.Float() < *c.Less
+	case c.LessOrEquals != nil:
+		return exists && value.Float() <= *c.LessOrEquals
+	}
+	return false
+}
+
+// equals compares a JSON value with a YAML value. Strings are compared case insensitively, as in Azure Policy.
+func equals(value gjson.Result, expected interface{}) bool {
+	switch e := expected.(type) {
+	case string:
+		return value.Exists() && strings.EqualFold(value.String(), e)
+	case bool:
+		return (value.Type == gjson.True || value.Type == gjson.False) && value.Bool() == e
+	case int:
+		return value.Type == gjson.Number && value.Float() == float64(e)
+	case float64:
+		return value.Type == gjson.Number && value.Float() == e
+	}
+	return false
+}
+
+func in(value gjson.Result, values []interface{}) bool {
+	for _, v := range values {
+		if equals(value, v) {
+			return true
+		}
+	}
+	return false
+}
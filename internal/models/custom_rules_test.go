@@ -0,0 +1,200 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
+)
+
+const customRulesYaml = `
+- recommendationId: org-aks-001
+  resourceType: Microsoft.ContainerService/managedClusters
+  category: Security
+  impact: High
+  recommendation: AKS clusters should use Azure CNI
+  result: properties.networkProfile.networkPlugin
+  notCompliantWhen:
+    field: properties.networkProfile.networkPlugin
+    notEquals: azure
+- recommendationId: org-aks-002
+  resourceType: Microsoft.ContainerService/managedClusters
+  category: HighAvailability
+  impact: Medium
+  recommendation: AKS clusters should have at least 2 node pools
+  notCompliantWhen:
+    anyOf:
+      - field: properties.agentPoolProfiles
+        exists: false
+      - field: properties.agentPoolProfiles.#
+        less: 2
+`
+
+func TestCustomRules_Evaluate(t *testing.T) {
+	registerFakeScanner(t, "Microsoft.ContainerService/managedClusters")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "aks.yaml"), []byte(customRulesYaml), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadCustomRules(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scanContext := &ScanContext{
+		Filters:     NewFilters(),
+		CustomRules: rules,
+	}
+	engine := RecommendationEngine{}
+
+	tests := []struct {
+		name    string
+		cluster *armcontainerservice.ManagedCluster
+		rule    string
+		broken  bool
+		result  string
+	}{
+		{
+			name: "kubenet cluster is not compliant",
+			cluster: &armcontainerservice.ManagedCluster{
+				Type: to.Ptr("Microsoft.ContainerService/managedClusters"),
+				Properties: &armcontainerservice.ManagedClusterProperties{
+					NetworkProfile: &armcontainerservice.NetworkProfile{
+						NetworkPlugin: to.Ptr(armcontainerservice.NetworkPluginKubenet),
+					},
+				},
+			},
+			rule:   "org-aks-001",
+			broken: true,
+			result: "kubenet",
+		},
+		{
+			name: "azure cni cluster is compliant",
+			cluster: &armcontainerservice.ManagedCluster{
+				Type: to.Ptr("Microsoft.ContainerService/managedClusters"),
+				Properties: &armcontainerservice.ManagedClusterProperties{
+					NetworkProfile: &armcontainerservice.NetworkProfile{
+						NetworkPlugin: to.Ptr(armcontainerservice.NetworkPluginAzure),
+					},
+				},
+			},
+			rule:   "org-aks-001",
+			broken: false,
+			result: "azure",
+		},
+		{
+			name: "cluster without node pools is not compliant",
+			cluster: &armcontainerservice.ManagedCluster{
+				Type:       to.Ptr("Microsoft.ContainerService/managedClusters"),
+				Properties: &armcontainerservice.ManagedClusterProperties{},
+			},
+			rule:   "org-aks-002",
+			broken: true,
+		},
+		{
+			name: "cluster with 2 node pools is compliant",
+			cluster: &armcontainerservice.ManagedCluster{
+				Type: to.Ptr("Microsoft.ContainerService/managedClusters"),
+				Properties: &armcontainerservice.ManagedClusterProperties{
+					AgentPoolProfiles: []*armcontainerservice.ManagedClusterAgentPoolProfile{
+						{Name: to.Ptr("system")},
+						{Name: to.Ptr("user")},
+					},
+				},
+			},
+			rule:   "org-aks-002",
+			broken: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := engine.EvaluateRecommendations(map[string]AzqrRecommendation{}, tt.cluster, scanContext)
+			got, ok := results[tt.rule]
+			if !ok {
+				t.Fatalf("custom rule %s was not evaluated", tt.rule)
+			}
+			if got.NotCompliant != tt.broken || got.Result != tt.result {
+				t.Errorf("%s = (%v, %s), want (%v, %s)", tt.rule, got.NotCompliant, got.Result, tt.broken, tt.result)
+			}
+		})
+	}
+}
+
+func TestLoadCustomRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{name: "missing condition", yaml: "- {recommendationId: x, resourceType: t, recommendation: r, impact: High}"},
+		{name: "invalid impact", yaml: "- {recommendationId: x, resourceType: t, recommendation: r, impact: Huge, notCompliantWhen: {field: a, exists: true}}"},
+		{name: "two operators", yaml: "- {recommendationId: x, resourceType: t, recommendation: r, impact: Low, notCompliantWhen: {field: a, exists: true, equals: b}}"},
+		{name: "operator without field", yaml: "- {recommendationId: x, resourceType: t, recommendation: r, impact: Low, notCompliantWhen: {equals: b}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "rules.yml"), []byte(tt.yaml), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadCustomRules(dir); err == nil {
+				t.Errorf("LoadCustomRules() must fail for %s", tt.name)
+			}
+		})
+	}
+}
+
+type fakeScanner struct {
+	recommendations map[string]AzqrRecommendation
+	resourceTypes   []string
+}
+
+func (s *fakeScanner) Init(config *ScannerConfig) error { return nil }
+func (s *fakeScanner) GetRecommendations() map[string]AzqrRecommendation {
+	return s.recommendations
+}
+func (s *fakeScanner) Scan(scanContext *ScanContext) ([]AzqrServiceResult, error) { return nil, nil }
+func (s *fakeScanner) ResourceTypes() []string                                    { return s.resourceTypes }
+
+// registerFakeScanner registers a scanner of the resource types, so custom rules can target them
+func registerFakeScanner(t *testing.T, resourceTypes ...string) {
+	t.Helper()
+	ScannerList["fake"] = []IAzureScanner{&fakeScanner{resourceTypes: resourceTypes}}
+	t.Cleanup(func() {
+		delete(ScannerList, "fake")
+	})
+}
+
+func TestLoadCustomRules_BuiltInID(t *testing.T) {
+	ScannerList["fake"] = []IAzureScanner{&fakeScanner{
+		recommendations: map[string]AzqrRecommendation{"aks-001": {RecommendationID: "aks-001"}},
+		resourceTypes:   []string{"Microsoft.ContainerService/managedClusters"},
+	}}
+	defer delete(ScannerList, "fake")
+
+	dir := t.TempDir()
+	yaml := "- {recommendationId: AKS-001, resourceType: Microsoft.ContainerService/managedClusters, recommendation: r, impact: Low, notCompliantWhen: {field: a, exists: true}}"
+	if err := os.WriteFile(filepath.Join(dir, "rules.yml"), []byte(yaml), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCustomRules(dir); err == nil {
+		t.Error("LoadCustomRules() must reject the id of a built-in recommendation")
+	}
+}
+
+func TestLoadCustomRules_UnknownResourceType(t *testing.T) {
+	registerFakeScanner(t, "Microsoft.ContainerService/managedClusters")
+
+	dir := t.TempDir()
+	yaml := "- {recommendationId: org-aks-001, resourceType: Microsoft.ContainerService/managedCluster, recommendation: r, impact: Low, notCompliantWhen: {field: a, exists: true}}"
+	if err := os.WriteFile(filepath.Join(dir, "rules.yml"), []byte(yaml), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCustomRules(dir); err == nil {
+		t.Error("LoadCustomRules() must reject a resource type that no scanner scans")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/tidwall/gjson"
+)
+
+// Evidence - Property inspected by a recommendation and the value observed
+type Evidence struct {
+	Path  string
+	Value string
+}
+
+const (
+	scanContextPrefix = "scanContext."
+	maxEvidenceLength = 256
+)
+
+// String returns the evidence as path = value
+func (e Evidence) String() string {
+	return fmt.Sprintf("%s = %s", e.Path, e.Value)
+}
+
+// collectEvidence resolves the evidence paths of a rule. Paths are either Go field paths of the target,
+// e.g. Properties.NetworkProfile.OutboundType, Go field paths of the scan context, e.g. scanContext.DiagnosticsSettings,
+// or GJSON paths of the ARM JSON of the target, e.g. properties.networkProfile.outboundType.
+// Maps of the scan context are indexed by the ID of the target.
+func collectEvidence(paths []string, target interface{}, scanContext *ScanContext) []Evidence {
+	evidence := []Evidence{}
+	var doc []byte
+	for _, p := range paths {
+		switch {
+		case strings.HasPrefix(p, scanContextPrefix):
+			path, value := resolveField(reflect.ValueOf(scanContext), strings.Split(strings.TrimPrefix(p, scanContextPrefix), "."), targetID(target))
+			evidence = append(evidence, Evidence{Path: path, Value: value})
+		case p != "" && unicode.IsUpper(rune(p[0])):
+			path, value := resolveField(reflect.ValueOf(target), strings.Split(p, "."), "")
+			evidence = append(evidence, Evidence{Path: path, Value: value})
+		default:
+			if doc == nil {
+				var err error
+				doc, err = json.Marshal(target)
+				if err != nil {
+					continue
+				}
+			}
+			value := gjson.GetBytes(doc, p)
+			if !value.Exists() {
+				evidence = append(evidence, Evidence{Path: p, Value: "null"})
+				continue
+			}
+			evidence = append(evidence, Evidence{Path: p, Value: truncate(value.String())})
+		}
+	}
+	return evidence
+}
+
+// resolveField walks the fields of v, returning the JSON path and value found.
+// Maps are indexed by the lower case id, if set.
+func resolveField(v reflect.Value, fields []string, id string) (string, string) {
+	path := []string{}
+	for i, name := range fields {
+		v = indirect(v)
+		if !v.IsValid() {
+			return strings.Join(append(path, lowerCamel(fields[i:])...), "."), "null"
+		}
+		if v.Kind() != reflect.Struct {
+			return strings.Join(append(path, lowerCamel(fields[i:])...), "."), "unknown"
+		}
+
+		f, ok := v.Type().FieldByName(name)
+		if !ok {
+			return strings.Join(append(path, lowerCamel(fields[i:])...), "."), "unknown"
+		}
+		path = append(path, jsonName(f))
+
+		v, _ = v.FieldByIndexErr(f.Index)
+		if id != "" && indirect(v).Kind() == reflect.Map && indirect(v).Type().Key().Kind() == reflect.String {
+			m := indirect(v)
+			v = m.MapIndex(reflect.ValueOf(strings.ToLower(id)).Convert(m.Type().Key()))
+			if !v.IsValid() {
+				v = reflect.Zero(m.Type().Elem())
+			}
+		}
+	}
+	return strings.Join(path, "."), format(v)
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func format(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return "null"
+	}
+	if v.Kind() == reflect.String {
+		return truncate(v.String())
+	}
+	if !v.CanInterface() {
+		return "unknown"
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return truncate(string(b))
+}
+
+// jsonName returns the JSON name of a struct field
+func jsonName(f reflect.StructField) string {
+	if tag, ok := f.Tag.Lookup("json"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return lowerCamel([]string{f.Name})[0]
+}
+
+func lowerCamel(names []string) []string {
+	r := make([]string, len(names))
+	for i, n := range names {
+		if n != "" {
+			r[i] = strings.ToLower(n[:1]) + n[1:]
+		}
+	}
+	return r
+}
+
+func targetID(target interface{}) string {
+	v := indirect(reflect.ValueOf(target))
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return ""
+	}
+	id := indirect(v.FieldByName("ID"))
+	if !id.IsValid() || id.Kind() != reflect.String {
+		return ""
+	}
+	return id.String()
+}
+
+func truncate(s string) string {
+	if len(s) > maxEvidenceLength {
+		return s[:maxEvidenceLength] + "..."
+	}
+	return s
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"testing"
+)
+
+func TestCollectEvidence(t *testing.T) {
+	type properties struct {
+		OutboundType *string `json:"outboundType,omitempty"`
+		Replicas     *int32  `json:"replicas,omitempty"`
+	}
+	type target struct {
+		ID         *string     `json:"id,omitempty"`
+		Properties *properties `json:"properties,omitempty"`
+	}
+
+	id := "/subscriptions/xxx/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks"
+	outboundType := "loadBalancer"
+	replicas := int32(3)
+	resource := &target{ID: &id, Properties: &properties{OutboundType: &outboundType, Replicas: &replicas}}
+	scanContext := &ScanContext{
+		DiagnosticsSettings: map[string]bool{"/subscriptions/xxx/resourcegroups/rg/providers/microsoft.containerservice/managedclusters/aks": true},
+	}
+
+	tests := []struct {
+		name   string
+		target *target
+		path   string
+		want   string
+	}{
+		{name: "string field", target: resource, path: "Properties.OutboundType", want: "properties.outboundType = loadBalancer"},
+		{name: "number field", target: resource, path: "Properties.Replicas", want: "properties.replicas = 3"},
+		{name: "nil field", target: &target{ID: &id}, path: "Properties.OutboundType", want: "properties.outboundType = null"},
+		{name: "unknown field", target: resource, path: "Properties.Sku", want: "properties.sku = unknown"},
+		{name: "scan context indexed by id", target: resource, path: "scanContext.DiagnosticsSettings", want: "diagnosticsSettings = true"},
+		{name: "gjson path", target: resource, path: "properties.outboundType", want: "properties.outboundType = loadBalancer"},
+		{name: "missing gjson path", target: resource, path: "properties.sku.name", want: "properties.sku.name = null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evidence := collectEvidence([]string{tt.path}, tt.target, scanContext)
+			if len(evidence) != 1 || evidence[0].String() != tt.want {
+				t.Errorf("collectEvidence() = %v, want %s", evidence, tt.want)
+			}
+		})
+	}
+}
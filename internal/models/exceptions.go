@@ -0,0 +1,171 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ExceptionDateLayout is the layout of the expiry date of the exceptions, e.g. 2025-12-31
+const ExceptionDateLayout = "2006-01-02"
+
+type (
+	// Exception - Time-boxed waiver of a recommendation for a resource, accepted by its owner.
+	// Findings covered by an active exception are not reported. Once expired, they are reported again.
+	Exception struct {
+		// Resource is the ID of the resource, or of the subscription or resource group covering all their resources
+		Resource       string `yaml:"resource" json:"resource"`
+		Recommendation string `yaml:"recommendation" json:"recommendation"`
+		Owner          string `yaml:"owner" json:"owner"`
+		Reason         string `yaml:"reason" json:"reason"`
+		// Expires is the last day, in UTC, the exception is active, e.g. 2025-12-31
+		Expires string `yaml:"expires" json:"expires"`
+
+		once    sync.Once
+		err     error
+		expires time.Time
+	}
+
+	// ExceptionStatus - Status of an exception at scan time
+	ExceptionStatus string
+
+	// ExceptionResult - Exception with its status and the number of findings it covers
+	ExceptionResult struct {
+		Exception *Exception
+		Status    ExceptionStatus
+		// Findings is the number of findings waived by an active exception, or reported again by an expired one
+		Findings int
+	}
+)
+
+const (
+	ExceptionActive  ExceptionStatus = "Active"
+	ExceptionExpired ExceptionStatus = "Expired"
+	// ExceptionInvalid is the status of an exception that fails validation. It waives no finding.
+	ExceptionInvalid ExceptionStatus = "Invalid"
+)
+
+// Status returns ExceptionExpired if the exception expired before now, or ExceptionInvalid if it is not valid
+func (x *Exception) Status(now time.Time) ExceptionStatus {
+	if err := x.compiled(); err != nil {
+		return ExceptionInvalid
+	}
+	if !now.Before(x.expires.AddDate(0, 0, 1)) {
+		return ExceptionExpired
+	}
+	return ExceptionActive
+}
+
+// compileExceptions validates the exceptions and parses their expiry dates
+func compileExceptions(exceptions []*Exception) error {
+	for i, x := range exceptions {
+		if x == nil {
+			return fmt.Errorf("exception %d requires a resource and a recommendation", i+1)
+		}
+		if err := x.compiled(); err != nil {
+			return fmt.Errorf("exception %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// compiled validates the exception and parses its expiry date once, also when it was not loaded from a filters file
+func (x *Exception) compiled() error {
+	x.once.Do(func() {
+		x.err = x.compile()
+	})
+	return x.err
+}
+
+// compile validates the exception and parses its expiry date
+func (x *Exception) compile() error {
+	if x.Resource == "" || x.Recommendation == "" {
+		return fmt.Errorf("exception requires a resource and a recommendation")
+	}
+	if x.Owner == "" || x.Reason == "" {
+		return fmt.Errorf("exception for %s on %s requires an owner and a reason", x.Recommendation, x.Resource)
+	}
+
+	expires, err := time.Parse(ExceptionDateLayout, x.Expires)
+	if err != nil {
+		return fmt.Errorf("invalid expiry date of exception for %s on %s: %w", x.Recommendation, x.Resource, err)
+	}
+	x.expires = expires
+	return nil
+}
+
+func (x *Exception) matches(resourceID, recommendationID string) bool {
+	if !strings.EqualFold(x.Recommendation, recommendationID) {
+		return false
+	}
+
+	id := strings.ToLower(resourceID)
+	scope := strings.ToLower(strings.TrimSuffix(x.Resource, "/"))
+	return id == scope || strings.HasPrefix(id, scope+"/")
+}
+
+// ApplyExceptions marks the AZQR findings covered by an active exception with StatusExcepted
+// and removes the APRL ones. Findings covered by expired exceptions are reported as usual.
+// Invalid exceptions waive no finding.
+// Returns the APRL results to report and the status of every exception.
+func (e *AzqrFilter) ApplyExceptions(azqr []AzqrServiceResult, aprl []AprlResult, now time.Time) ([]AprlResult, []ExceptionResult) {
+	results := []ExceptionResult{}
+	for _, x := range e.Exceptions {
+		if x == nil {
+			continue
+		}
+		r := ExceptionResult{Exception: x, Status: x.Status(now)}
+		switch r.Status {
+		case ExceptionExpired:
+			log.Warn().Msgf("Exception for %s on %s owned by %s expired on %s", x.Recommendation, x.Resource, x.Owner, x.Expires)
+		case ExceptionInvalid:
+			log.Warn().Err(x.compiled()).Msg("Ignoring invalid exception")
+		}
+		results = append(results, r)
+	}
+
+	// excepted returns true if an active exception covers the finding, counting it in the exception
+	excepted := func(resourceID, recommendationID string) bool {
+		var expired *ExceptionResult
+		for i := range results {
+			if results[i].Status == ExceptionInvalid || !results[i].Exception.matches(resourceID, recommendationID) {
+				continue
+			}
+			if results[i].Status == ExceptionActive {
+				results[i].Findings++
+				return true
+			}
+			if expired == nil {
+				expired = &results[i]
+			}
+		}
+		if expired != nil {
+			expired.Findings++
+		}
+		return false
+	}
+
+	for _, d := range azqr {
+		for id, r := range d.Recommendations {
+			if r.NotCompliant && excepted(d.ResourceID(), r.RecommendationID) {
+				r.NotCompliant = false
+				r.Status = StatusExcepted
+				d.Recommendations[id] = r
+			}
+		}
+	}
+
+	reported := []AprlResult{}
+	for _, r := range aprl {
+		if !excepted(r.ResourceID, r.RecommendationID) {
+			reported = append(reported, r)
+		}
+	}
+	return reported, results
+}
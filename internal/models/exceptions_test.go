@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAzqrFilter_ApplyExceptions(t *testing.T) {
+	exceptions := []*Exception{
+		{Resource: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks", Recommendation: "aks-004", Owner: "secops", Reason: "approved", Expires: "2025-06-30"},
+		{Resource: "/subscriptions/sub/resourceGroups/rg", Recommendation: "guid-1", Owner: "secops", Reason: "legacy", Expires: "2025-06-30"},
+		{Resource: "/subscriptions/sub", Recommendation: "aks-012", Owner: "platform", Reason: "migration", Expires: "2025-01-31"},
+	}
+	filter := &AzqrFilter{Exceptions: exceptions}
+
+	azqr := []AzqrServiceResult{
+		{
+			SubscriptionID: "sub",
+			ResourceGroup:  "rg",
+			Type:           "Microsoft.ContainerService/managedClusters",
+			ServiceName:    "aks",
+			Recommendations: map[string]AzqrResult{
+				"aks-004": {RecommendationID: "aks-004", NotCompliant: true, Status: StatusNotCompliant},
+				"aks-012": {RecommendationID: "aks-012", NotCompliant: true, Status: StatusNotCompliant},
+			},
+		},
+	}
+	aprl := []AprlResult{
+		{RecommendationID: "guid-1", ResourceID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"},
+		{RecommendationID: "guid-1", ResourceID: "/subscriptions/sub/resourceGroups/other/providers/Microsoft.Storage/storageAccounts/st"},
+	}
+
+	// the last day of an exception is still active
+	now := time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC)
+	reported, results := filter.ApplyExceptions(azqr, aprl, now)
+
+	if r := azqr[0].Recommendations["aks-004"]; r.NotCompliant || r.Status != StatusExcepted {
+		t.Errorf("aks-004 status = %s, want %s", r.Status, StatusExcepted)
+	}
+	if r := azqr[0].Recommendations["aks-012"]; !r.NotCompliant || r.Status != StatusNotCompliant {
+		t.Errorf("aks-012 status = %s, want %s", r.Status, StatusNotCompliant)
+	}
+	if len(reported) != 1 || reported[0].ResourceID != aprl[1].ResourceID {
+		t.Errorf("reported %v, want the storage account in resource group other", reported)
+	}
+
+	want := []struct {
+		status   ExceptionStatus
+		findings int
+	}{
+		{ExceptionActive, 1},
+		{ExceptionActive, 1},
+		{ExceptionExpired, 1},
+	}
+	for i, w := range want {
+		if results[i].Status != w.status || results[i].Findings != w.findings {
+			t.Errorf("exception %d = (%s, %d), want (%s, %d)", i, results[i].Status, results[i].Findings, w.status, w.findings)
+		}
+	}
+}
+
+// TestAzqrFilter_ApplyExceptions_Invalid applies exceptions built in code, without loading a filters file
+func TestAzqrFilter_ApplyExceptions_Invalid(t *testing.T) {
+	const aksID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks"
+	filter := &AzqrFilter{Exceptions: []*Exception{
+		{Resource: aksID, Recommendation: "aks-004", Owner: "secops", Reason: "approved", Expires: "31/12/2999"},
+		{Resource: aksID, Recommendation: "aks-012", Reason: "approved", Expires: "2999-12-31"},
+		nil,
+	}}
+	azqr := []AzqrServiceResult{
+		{
+			SubscriptionID: "sub",
+			ResourceGroup:  "rg",
+			Type:           "Microsoft.ContainerService/managedClusters",
+			ServiceName:    "aks",
+			Recommendations: map[string]AzqrResult{
+				"aks-004": {RecommendationID: "aks-004", NotCompliant: true, Status: StatusNotCompliant},
+				"aks-012": {RecommendationID: "aks-012", NotCompliant: true, Status: StatusNotCompliant},
+			},
+		},
+	}
+
+	_, results := filter.ApplyExceptions(azqr, nil, time.Now())
+
+	for id, r := range azqr[0].Recommendations {
+		if !r.NotCompliant || r.Status != StatusNotCompliant {
+			t.Errorf("%s status = %s, want %s", id, r.Status, StatusNotCompliant)
+		}
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d exception results, want 2", len(results))
+	}
+	for i, r := range results {
+		if r.Status != ExceptionInvalid || r.Findings != 0 {
+			t.Errorf("exception %d = (%s, %d), want (%s, 0)", i, r.Status, r.Findings, ExceptionInvalid)
+		}
+	}
+}
+
+func TestCompileExceptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		exception *Exception
+		wantErr   bool
+	}{
+		{name: "valid", exception: &Exception{Resource: "/subscriptions/sub", Recommendation: "aks-004", Owner: "secops", Reason: "approved", Expires: "2025-12-31"}, wantErr: false},
+		{name: "without owner", exception: &Exception{Resource: "/subscriptions/sub", Recommendation: "aks-004", Reason: "approved", Expires: "2025-12-31"}, wantErr: true},
+		{name: "without expiry date", exception: &Exception{Resource: "/subscriptions/sub", Recommendation: "aks-004", Owner: "secops", Reason: "approved"}, wantErr: true},
+		{name: "invalid expiry date", exception: &Exception{Resource: "/subscriptions/sub", Recommendation: "aks-004", Owner: "secops", Reason: "approved", Expires: "31/12/2025"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := compileExceptions([]*Exception{tt.exception}); (err != nil) != tt.wantErr {
+				t.Errorf("compileExceptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@
 package models
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -16,8 +17,11 @@ type (
 	}
 
 	AzqrFilter struct {
-		Include          *IncludeFilter `yaml:"include" json:"include"`
-		Exclude          *ExcludeFilter `yaml:"exclude" json:"exclude"`
+		Include          *IncludeFilter    `yaml:"include" json:"include"`
+		Exclude          *ExcludeFilter    `yaml:"exclude" json:"exclude"`
+		Naming           *NamingConvention `yaml:"naming" json:"naming"`
+		Tags             *TagPolicy        `yaml:"tags" json:"tags"`
+		Exceptions       []*Exception      `yaml:"exceptions" json:"exceptions"`
 		iSubscriptions   map[string]bool
 		iResourceGroups  map[string]bool
 		iResourceTypes   map[string]bool
@@ -125,24 +129,40 @@ func NewFilters() *Filters {
 				Services:        []string{},
 				Recommendations: []string{},
 			},
-			Scanners: []IAzureScanner{},
+			Exceptions: []*Exception{},
+			Scanners:   []IAzureScanner{},
 		},
 	}
 	return filters
 }
 
-func LoadFilters(filterFile string, scannerKeys []string) *Filters {
+func LoadFilters(filterFile string, scannerKeys []string) (*Filters, error) {
 	filters := NewFilters()
 
 	if filterFile != "" {
 		data, err := os.ReadFile(filterFile)
 		if err != nil {
-			log.Fatal().Err(err).Msgf("failed reading data from file: %s", filterFile)
+			return nil, fmt.Errorf("failed reading data from file: %s: %w", filterFile, err)
 		}
 
 		err = yaml.Unmarshal([]byte(data), &filters)
 		if err != nil {
-			log.Fatal().Err(err).Msgf("failed parsing yaml from file: %s", filterFile)
+			return nil, fmt.Errorf("failed parsing yaml from file: %s: %w", filterFile, err)
+		}
+
+		err = filters.Azqr.Naming.compiled()
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing naming convention from file: %s: %w", filterFile, err)
+		}
+
+		err = filters.Azqr.Tags.compiled()
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tag policy from file: %s: %w", filterFile, err)
+		}
+
+		err = compileExceptions(filters.Azqr.Exceptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing exceptions from file: %s: %w", filterFile, err)
 		}
 	}
 
@@ -205,7 +225,7 @@ func LoadFilters(filterFile string, scannerKeys []string) *Filters {
 		}
 	}
 
-	return filters
+	return filters, nil
 }
 
 func (e *AzqrFilter) isResourceGroupExcluded(resourceGroupID string) bool {
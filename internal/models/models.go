@@ -5,8 +5,10 @@ package models
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -18,6 +20,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 	"github.com/rs/zerolog/log"
+	"github.com/tidwall/gjson"
 )
 
 type (
@@ -33,10 +36,24 @@ type (
 
 	// ScanContext - Struct for Scanner Context
 	ScanContext struct {
-		Filters               *Filters
-		PrivateEndpoints      map[string]bool
-		DiagnosticsSettings   map[string]bool
-		PublicIPs             map[string]*armnetwork.PublicIPAddress
+		Filters             *Filters
+		PrivateEndpoints    map[string]bool
+		DiagnosticsSettings map[string]bool
+		PublicIPs           map[string]*armnetwork.PublicIPAddress
+		CustomRules         CustomRules
+		// ResourceGroupTags are the tags per lower case resource group ID, set when the tag policy has inherited tags
+		ResourceGroupTags map[string]map[string]*string
+		// Resources is the inventory of the scanned subscriptions, shared by all scanners. Rules must not modify it.
+		Resources *ResourceIndex
+		// ResourceData is the auxiliary data of the resource being evaluated, set with WithResource
+		ResourceData
+
+		// document is the ARM JSON of the resource being evaluated by custom rules, marshalled once per resource
+		document []byte
+	}
+
+	// ResourceData - Auxiliary data of a resource fetched by its scanner, besides the resource itself
+	ResourceData struct {
 		SiteConfig            *armappservice.WebAppsClientGetConfigurationResponse
 		BlobServiceProperties *armstorage.BlobServicesClientGetServicePropertiesResponse
 	}
@@ -68,7 +85,14 @@ type (
 		Impact             RecommendationImpact
 		RecommendationType RecommendationType
 		LearnMoreUrl       string
-		Eval               func(target interface{}, scanContext *ScanContext) (bool, string)
+		// Source of the recommendation, AZQR when empty
+		Source string
+		Eval   func(target interface{}, scanContext *ScanContext) (bool, string)
+		// Applies, if set, returns false when the recommendation is not applicable to the target,
+		// e.g. availability zones in regions without zones. Eval is not called then.
+		Applies func(target interface{}, scanContext *ScanContext) bool
+		// Evidence are the paths of the properties inspected by Eval, recorded with their values when the target is not compliant
+		Evidence []string
 	}
 
 	AzqrResult struct {
@@ -81,6 +105,14 @@ type (
 		LearnMoreUrl       string
 		NotCompliant       bool
 		Result             string
+		// Source of the recommendation, AZQR when empty
+		Source string
+		// Status of the evaluation. NotCompliant is only set with StatusNotCompliant.
+		Status ResultStatus
+		// Evidence are the properties inspected and their values
+		Evidence []Evidence
+		// SuppressionReason is the reason given by the azqr-suppress tag of a resource with StatusSuppressed
+		SuppressionReason string
 	}
 
 	Resource struct {
@@ -94,6 +126,9 @@ type (
 		SkuTier        string
 		Kind           string
 		SLA            string
+		Tags           map[string]*string
+		// Properties are the ARM properties of the IndexedPropertyTypes, nil for other types
+		Properties map[string]interface{} `json:",omitempty"`
 	}
 
 	ResourceTypeCount struct {
@@ -148,6 +183,8 @@ type (
 		Param5              string
 		AutomationAvailable string
 		Source              string
+		// SuppressionReason is the reason given by the azqr-suppress tag of a suppressed resource
+		SuppressionReason string
 	}
 
 	DefenderRecommendation struct {
@@ -186,14 +223,36 @@ type (
 		RecommendationID, SubscriptionID, SubscriptionName, Type, Name, ResourceID, Category, Impact, Description string
 	}
 
+	// ScanError - Failure of a scanner
+	ScanError struct {
+		SubscriptionID   string
+		SubscriptionName string
+		Scanner          string
+		ResourceType     string
+		Error            string
+		// Unassessed is set when the resources covered by the scanner were not assessed,
+		// as opposed to failures collecting costs, Advisor or Defender data, or data shared by the scanners
+		Unassessed bool
+	}
+
 	RecommendationEngine struct{}
 
 	RecommendationImpact   string
 	RecommendationCategory string
 	RecommendationType     string
+	ResultStatus           string
 )
 
 const (
+	StatusCompliant     ResultStatus = "Compliant"
+	StatusNotCompliant  ResultStatus = "NotCompliant"
+	StatusNotApplicable ResultStatus = "NotApplicable"
+	StatusError         ResultStatus = "Error"
+	// StatusSuppressed is a not compliant result suppressed by the azqr-suppress tag of the resource
+	StatusSuppressed ResultStatus = "Suppressed"
+	// StatusExcepted is a not compliant result waived by an active exception of the filters file
+	StatusExcepted ResultStatus = "Excepted"
+
 	ImpactHigh   RecommendationImpact = "High"
 	ImpactMedium RecommendationImpact = "Medium"
 	ImpactLow    RecommendationImpact = "Low"
@@ -213,6 +272,10 @@ const (
 )
 
 func (r *AzqrRecommendation) ToAzureAprlRecommendation() AprlRecommendation {
+	source := r.Source
+	if source == "" {
+		source = "AZQR"
+	}
 	return AprlRecommendation{
 		RecommendationID:    r.RecommendationID,
 		Recommendation:      r.Recommendation,
@@ -230,10 +293,18 @@ func (r *AzqrRecommendation) ToAzureAprlRecommendation() AprlRecommendation {
 			Name string "yaml:\"name\""
 			Url  string "yaml:\"url\""
 		}{{Name: "Learn More", Url: r.LearnMoreUrl}},
-		Source: "AZQR",
+		Source: source,
 	}
 }
 
+// WithResource returns a copy of the scan context with the auxiliary data of the resource being evaluated.
+// The scan context is shared by the scanners running concurrently, so scanners must not set its fields.
+func (c *ScanContext) WithResource(data ResourceData) *ScanContext {
+	evalContext := *c
+	evalContext.ResourceData = data
+	return &evalContext
+}
+
 func (e *RecommendationEngine) EvaluateRecommendations(rules map[string]AzqrRecommendation, target interface{}, scanContext *ScanContext) map[string]AzqrResult {
 	results := map[string]AzqrResult{}
 
@@ -245,22 +316,83 @@ func (e *RecommendationEngine) EvaluateRecommendations(rules map[string]AzqrReco
 		results[k] = e.evaluateRecommendation(rule, target, scanContext)
 	}
 
+	customRules, evalContext := e.customRules(target, scanContext)
+	for k, rule := range customRules {
+		if scanContext.Filters.Azqr.IsRecommendationExcluded(rule.RecommendationID) {
+			continue
+		}
+
+		results[k] = e.evaluateRecommendation(rule, target, evalContext)
+	}
+
 	return results
 }
 
-func (e *RecommendationEngine) evaluateRecommendation(rule AzqrRecommendation, target interface{}, scanContext *ScanContext) AzqrResult {
-	broken, result := rule.Eval(target, scanContext)
+// customRules returns the custom rules matching the ARM type of the target,
+// and a copy of the scan context holding the ARM JSON of the target they evaluate
+func (e *RecommendationEngine) customRules(target interface{}, scanContext *ScanContext) (map[string]AzqrRecommendation, *ScanContext) {
+	if len(scanContext.CustomRules) == 0 {
+		return nil, scanContext
+	}
 
-	return AzqrResult{
+	doc, err := json.Marshal(target)
+	if err != nil {
+		return nil, scanContext
+	}
+	evalContext := *scanContext
+	evalContext.document = doc
+	return scanContext.CustomRules.ForResourceTypes([]string{gjson.GetBytes(doc, "type").String()}), &evalContext
+}
+
+// targetJSON returns the ARM JSON of the target, marshalled once per resource when evaluated by the engine
+func (c *ScanContext) targetJSON(target interface{}) ([]byte, error) {
+	if c != nil && c.document != nil {
+		return c.document, nil
+	}
+	return json.Marshal(target)
+}
+
+// evaluateRecommendation evaluates a rule. A panicking rule is reported with StatusError instead of stopping the scan.
+func (e *RecommendationEngine) evaluateRecommendation(rule AzqrRecommendation, target interface{}, scanContext *ScanContext) (result AzqrResult) {
+	result = AzqrResult{
 		RecommendationID:   rule.RecommendationID,
 		Category:           rule.Category,
 		Recommendation:     rule.Recommendation,
 		RecommendationType: rule.RecommendationType,
 		Impact:             rule.Impact,
 		LearnMoreUrl:       rule.LearnMoreUrl,
-		Result:             result,
-		NotCompliant:       broken,
+		Source:             rule.Source,
+		Status:             StatusCompliant,
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Warn().Msgf("Recommendation %s failed: %v", rule.RecommendationID, r)
+			result.NotCompliant = false
+			result.Result = fmt.Sprint(r)
+			result.Status = StatusError
+		}
+	}()
+
+	if rule.Applies != nil && !rule.Applies(target, scanContext) {
+		result.Status = StatusNotApplicable
+		return result
+	}
+
+	broken, res := rule.Eval(target, scanContext)
+	result.Result = res
+	result.NotCompliant = broken
+	if broken {
+		result.Status = StatusNotCompliant
+		result.Evidence = collectEvidence(rule.Evidence, target, scanContext)
+
+		if suppression := suppressionOf(target); suppression.Suppresses(rule.RecommendationID) {
+			result.NotCompliant = false
+			result.Status = StatusSuppressed
+			result.SuppressionReason = suppression.Reason
+		}
 	}
+	return result
 }
 
 func (r *AzqrServiceResult) ResourceID() string {
@@ -279,6 +411,31 @@ func LogGraphRecommendationScan(serviceType, recommendationId string) {
 	log.Info().Msgf("Scanning subscriptions for %s using rule %s", serviceType, recommendationId)
 }
 
+// NewScanError - Creates a ScanError and logs the failure
+func NewScanError(subscriptionID, subscriptionName, scanner, resourceType string, err error) ScanError {
+	log.Warn().Err(err).Msgf("%s scanner failed for %s. Continuing scan...", scanner, resourceType)
+	return ScanError{
+		SubscriptionID:   subscriptionID,
+		SubscriptionName: subscriptionName,
+		Scanner:          scanner,
+		ResourceType:     resourceType,
+		Error:            err.Error(),
+	}
+}
+
+// GetScannerKey returns the abbreviation under which the scanner type is registered in ScannerList
+func GetScannerKey(scanner IAzureScanner) string {
+	t := reflect.TypeOf(scanner)
+	for key, scanners := range ScannerList {
+		for _, s := range scanners {
+			if reflect.TypeOf(s) == t {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
 func ShouldSkipError(err error) bool {
 	var respErr *azcore.ResponseError
 	if errors.As(err, &respErr) {
@@ -347,6 +504,17 @@ func GetResourceTypeFromResourceID(resourceID string) string {
 	return fmt.Sprintf("%s/%s", parts[6], parts[7])
 }
 
+// NewScannerInstance returns a new, uninitialized scanner of the same type as scanner.
+// Scanners keep the configuration of the subscription they were initialized with, so each
+// subscription scanned concurrently needs its own instances.
+func NewScannerInstance(scanner IAzureScanner) IAzureScanner {
+	t := reflect.TypeOf(scanner)
+	if t.Kind() != reflect.Ptr {
+		return scanner
+	}
+	return reflect.New(t.Elem()).Interface().(IAzureScanner)
+}
+
 // ScannerList is a map of service abbreviation to scanner
 var ScannerList = map[string][]IAzureScanner{}
 
@@ -0,0 +1,64 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecommendationEngine_EvaluateRecommendations(t *testing.T) {
+	type target struct {
+		Location *string
+		Enabled  *bool
+		Tags     map[string]*string
+	}
+	rules := map[string]AzqrRecommendation{
+		"enabled": {
+			RecommendationID: "enabled",
+			Eval: func(t interface{}, scanContext *ScanContext) (bool, string) {
+				return !*t.(*target).Enabled, ""
+			},
+		},
+		"zones": {
+			RecommendationID: "zones",
+			Eval: func(t interface{}, scanContext *ScanContext) (bool, string) {
+				return true, ""
+			},
+			Applies: func(t interface{}, scanContext *ScanContext) bool {
+				return HasAvailabilityZones(t.(*target).Location)
+			},
+		},
+	}
+	enabled, disabled := true, false
+	westeurope, westcentralus := "westeurope", "westcentralus"
+
+	tests := []struct {
+		name   string
+		target *target
+		rule   string
+		status ResultStatus
+		result string
+	}{
+		{name: "compliant", target: &target{Enabled: &enabled}, rule: "enabled", status: StatusCompliant},
+		{name: "not compliant", target: &target{Enabled: &disabled}, rule: "enabled", status: StatusNotCompliant},
+		{name: "panic is an error", target: &target{}, rule: "enabled", status: StatusError, result: "nil pointer dereference"},
+		{name: "region with zones", target: &target{Location: &westeurope}, rule: "zones", status: StatusNotCompliant},
+		{name: "region without zones", target: &target{Location: &westcentralus}, rule: "zones", status: StatusNotApplicable},
+		{name: "suppressed by tag", target: &target{Enabled: &disabled, Tags: map[string]*string{SuppressTag: ptr("enabled;reason=accepted")}}, rule: "enabled", status: StatusSuppressed},
+	}
+	engine := RecommendationEngine{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := engine.EvaluateRecommendations(rules, tt.target, &ScanContext{Filters: NewFilters()})
+			got := results[tt.rule]
+			if got.Status != tt.status || !strings.Contains(got.Result, tt.result) {
+				t.Errorf("%s = (%s, %s), want (%s, %s)", tt.rule, got.Status, got.Result, tt.status, tt.result)
+			}
+			if got.NotCompliant != (tt.status == StatusNotCompliant) {
+				t.Errorf("%s NotCompliant = %v with status %s", tt.rule, got.NotCompliant, got.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type (
+	// NamingConvention - Naming policies of the filters file, replacing the Cloud Adoption Framework abbreviations
+	NamingConvention struct {
+		// Environments are the values accepted by the {environment} token. Any value if empty.
+		Environments []string `yaml:"environments,flow" json:"environments"`
+		// Regions are the values accepted by the {region} token. Any value if empty.
+		Regions []string `yaml:"regions,flow" json:"regions"`
+		// Default is the policy of the resource types without a policy
+		Default *NamingPolicy `yaml:"default" json:"default"`
+		// Policies per resource type, e.g. Microsoft.ContainerService/managedClusters
+		Policies map[string]*NamingPolicy `yaml:"policies" json:"policies"`
+
+		policies map[string]*NamingPolicy
+		// templates caches the compiled templates per template and prefix
+		templates sync.Map
+		once      sync.Once
+		err       error
+	}
+
+	// NamingPolicy - Regular expression or token template names must match.
+	// Templates support the {prefix}, {workload}, {environment}, {region} and {instance} tokens,
+	// and optional segments between square brackets, e.g. {prefix}-{workload}-{environment}[-{region}][-{instance}]
+	NamingPolicy struct {
+		Pattern  string `yaml:"pattern" json:"pattern"`
+		Template string `yaml:"template" json:"template"`
+
+		regex *regexp.Regexp
+	}
+)
+
+var templateToken = regexp.MustCompile(`\{[a-z]+\}`)
+
+// CheckNaming returns true if name does not comply with the naming convention of the resource type, and the expected pattern.
+// prefix is the Cloud Adoption Framework abbreviation of the resource, the only requirement when no convention is configured.
+func (c *ScanContext) CheckNaming(resourceType, prefix, name string) (bool, string) {
+	var naming *NamingConvention
+	if c != nil && c.Filters != nil && c.Filters.Azqr != nil {
+		naming = c.Filters.Azqr.Naming
+	}
+
+	if err := naming.compiled(); err != nil {
+		return true, err.Error()
+	}
+
+	policy := naming.policy(resourceType)
+	if policy == nil {
+		if strings.HasPrefix(name, prefix) {
+			return false, ""
+		}
+		return true, prefix + "*"
+	}
+
+	if policy.Pattern != "" {
+		if policy.regex.MatchString(name) {
+			return false, ""
+		}
+		return true, policy.Pattern
+	}
+
+	regex, err := naming.template(policy.Template, prefix)
+	if err != nil {
+		return true, err.Error()
+	}
+	if regex.MatchString(name) {
+		return false, ""
+	}
+	return true, strings.ReplaceAll(policy.Template, "{prefix}", prefix)
+}
+
+// compiled compiles the policies once, also when the naming convention was not loaded from a filters file
+func (n *NamingConvention) compiled() error {
+	if n == nil {
+		return nil
+	}
+	n.once.Do(func() {
+		n.err = n.compile()
+	})
+	return n.err
+}
+
+// compile validates the policies and compiles their patterns
+func (n *NamingConvention) compile() error {
+	if n == nil {
+		return nil
+	}
+
+	n.policies = map[string]*NamingPolicy{}
+	for t, p := range n.Policies {
+		if err := p.compile(n); err != nil {
+			return fmt.Errorf("invalid naming policy for %s: %w", t, err)
+		}
+		n.policies[strings.ToLower(t)] = p
+	}
+
+	if n.Default != nil {
+		if err := n.Default.compile(n); err != nil {
+			return fmt.Errorf("invalid default naming policy: %w", err)
+		}
+	}
+	return nil
+}
+
+func (n *NamingConvention) policy(resourceType string) *NamingPolicy {
+	if n == nil {
+		return nil
+	}
+	if p, ok := n.policies[strings.ToLower(resourceType)]; ok {
+		return p
+	}
+	return n.Default
+}
+
+// template returns the regular expression of a template for a prefix
+func (n *NamingConvention) template(template, prefix string) (*regexp.Regexp, error) {
+	key := template + "\x00" + prefix
+	if r, ok := n.templates.Load(key); ok {
+		return r.(*regexp.Regexp), nil
+	}
+
+	r, err := n.compileTemplate(template, prefix)
+	if err != nil {
+		return nil, err
+	}
+	n.templates.Store(key, r)
+	return r, nil
+}
+
+func (n *NamingConvention) compileTemplate(template, prefix string) (*regexp.Regexp, error) {
+	tokens := map[string]string{
+		"{prefix}":      regexp.QuoteMeta(prefix),
+		"{workload}":    "[A-Za-z0-9]+",
+		"{environment}": alternation(n.Environments),
+		"{region}":      alternation(n.Regions),
+		"{instance}":    "[0-9]+",
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	depth := 0
+	for i := 0; i < len(template); {
+		switch template[i] {
+		case '[':
+			depth++
+			b.WriteString("(?:")
+			i++
+		case ']':
+			if depth == 0 {
+				return nil, fmt.Errorf("unbalanced ] in naming template %s", template)
+			}
+			depth--
+			b.WriteString(")?")
+			i++
+		case '{':
+			token := templateToken.FindString(template[i:])
+			pattern, ok := tokens[token]
+			if token == "" || !strings.HasPrefix(template[i:], token) || !ok {
+				return nil, fmt.Errorf("unknown token at %s in naming template %s", template[i:], template)
+			}
+			b.WriteString("(?:" + pattern + ")")
+			i += len(token)
+		default:
+			j := strings.IndexAny(template[i:], "[]{")
+			if j < 0 {
+				j = len(template) - i
+			}
+			b.WriteString(regexp.QuoteMeta(template[i : i+j]))
+			i += j
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced [ in naming template %s", template)
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+func (p *NamingPolicy) compile(n *NamingConvention) error {
+	if p == nil || (p.Pattern == "") == (p.Template == "") {
+		return fmt.Errorf("exactly one of pattern or template is required")
+	}
+
+	if p.Pattern != "" {
+		r, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return err
+		}
+		p.regex = r
+		return nil
+	}
+
+	_, err := n.compileTemplate(p.Template, "")
+	return err
+}
+
+// alternation returns a regular expression matching any of the values, or any value if empty
+func alternation(values []string) string {
+	if len(values) == 0 {
+		return "[A-Za-z0-9]+"
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = regexp.QuoteMeta(v)
+	}
+	return strings.Join(quoted, "|")
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"testing"
+)
+
+func TestScanContext_CheckNaming(t *testing.T) {
+	naming := &NamingConvention{
+		Environments: []string{"dev", "prd"},
+		Default:      &NamingPolicy{Template: "{prefix}-{workload}-{environment}[-{region}][-{instance}]"},
+		Policies: map[string]*NamingPolicy{
+			"Microsoft.Compute/virtualMachines": {Pattern: "^[a-z]{3}vm[0-9]{2}$"},
+		},
+	}
+	// not compiled: filters built in code, not loaded from a file, are compiled on first use
+	configured := &ScanContext{Filters: &Filters{Azqr: &AzqrFilter{Naming: naming}}}
+
+	tests := []struct {
+		name         string
+		scanContext  *ScanContext
+		resourceType string
+		prefix       string
+		resource     string
+		broken       bool
+		result       string
+	}{
+		{name: "CAF prefix without convention", scanContext: &ScanContext{}, resourceType: "Microsoft.ContainerService/managedClusters", prefix: "aks", resource: "aks-test", broken: false, result: ""},
+		{name: "missing CAF prefix without convention", scanContext: &ScanContext{}, resourceType: "Microsoft.ContainerService/managedClusters", prefix: "aks", resource: "k8s-test", broken: true, result: "aks*"},
+		{name: "template with optional segments", scanContext: configured, resourceType: "Microsoft.ContainerService/managedClusters", prefix: "aks", resource: "aks-shop-prd-weu-01", broken: false, result: ""},
+		{name: "template without optional segments", scanContext: configured, resourceType: "Microsoft.ContainerService/managedClusters", prefix: "aks", resource: "aks-shop-dev", broken: false, result: ""},
+		{name: "template with unknown environment", scanContext: configured, resourceType: "Microsoft.ContainerService/managedClusters", prefix: "aks", resource: "aks-shop-qa", broken: true, result: "aks-{workload}-{environment}[-{region}][-{instance}]"},
+		{name: "pattern per resource type", scanContext: configured, resourceType: "Microsoft.Compute/virtualMachines", prefix: "vm", resource: "webvm01", broken: false, result: ""},
+		{name: "pattern per resource type not matched", scanContext: configured, resourceType: "Microsoft.Compute/virtualMachines", prefix: "vm", resource: "vm-web-dev", broken: true, result: "^[a-z]{3}vm[0-9]{2}$"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broken, result := tt.scanContext.CheckNaming(tt.resourceType, tt.prefix, tt.resource)
+			if broken != tt.broken || result != tt.result {
+				t.Errorf("CheckNaming() = (%v, %s), want (%v, %s)", broken, result, tt.broken, tt.result)
+			}
+		})
+	}
+}
+
+func TestNamingConvention_InvalidTemplate(t *testing.T) {
+	for _, template := range []string{"{prefix}-{app}", "{prefix}[-{instance}", "{prefix}]"} {
+		naming := &NamingConvention{Default: &NamingPolicy{Template: template}}
+		if err := naming.compile(); err == nil {
+			t.Errorf("compile() must fail for template %s", template)
+		}
+
+		scanContext := &ScanContext{Filters: &Filters{Azqr: &AzqrFilter{Naming: &NamingConvention{Default: &NamingPolicy{Template: template}}}}}
+		if broken, result := scanContext.CheckNaming("Microsoft.ContainerService/managedClusters", "aks", "aks-test"); !broken || result == "" {
+			t.Errorf("CheckNaming() = (%v, %s) for template %s, want the error", broken, result, template)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// IndexedPropertyTypes are the resource types whose ARM properties are kept in the inventory, for rules
+// to inspect related resources. The properties of other types are not kept, to limit the size of the inventory.
+var IndexedPropertyTypes = []string{
+	"Microsoft.Network/networkInterfaces",
+	"Microsoft.Network/virtualNetworks",
+}
+
+// ResourceIndex - Read only index of the resource inventory, used by rules to query related resources.
+// Resources are indexed by lower case ARM ID, type and resource group, and nested resources by parent.
+type ResourceIndex struct {
+	byID            map[string]*Resource
+	byType          map[string][]*Resource
+	byResourceGroup map[string][]*Resource
+	byParent        map[string][]*Resource
+}
+
+// NewResourceIndex creates an index of the resources. The first resource with a given ID wins.
+func NewResourceIndex(resources ...[]*Resource) *ResourceIndex {
+	i := &ResourceIndex{
+		byID:            map[string]*Resource{},
+		byType:          map[string][]*Resource{},
+		byResourceGroup: map[string][]*Resource{},
+		byParent:        map[string][]*Resource{},
+	}
+
+	for _, list := range resources {
+		for _, r := range list {
+			if r == nil || r.ID == "" {
+				continue
+			}
+
+			id := strings.ToLower(r.ID)
+			if _, ok := i.byID[id]; ok {
+				continue
+			}
+			i.byID[id] = r
+
+			t := strings.ToLower(r.Type)
+			i.byType[t] = append(i.byType[t], r)
+
+			rg := strings.ToLower(GetResourceGroupIDFromResourceID(r.ID))
+			i.byResourceGroup[rg] = append(i.byResourceGroup[rg], r)
+
+			if parent := parentID(id); parent != "" {
+				i.byParent[parent] = append(i.byParent[parent], r)
+			}
+		}
+	}
+	return i
+}
+
+// Len returns the number of resources in the index
+func (i *ResourceIndex) Len() int {
+	if i == nil {
+		return 0
+	}
+	return len(i.byID)
+}
+
+// Get returns the resource with the ARM ID
+func (i *ResourceIndex) Get(id string) (*Resource, bool) {
+	if i == nil {
+		return nil, false
+	}
+	r, ok := i.byID[strings.ToLower(strings.TrimSuffix(id, "/"))]
+	return r, ok
+}
+
+// Owner returns the indexed resource with the ARM ID or, for IDs of sub resources not in the inventory
+// like subnets or IP configurations, the nearest indexed resource containing them.
+func (i *ResourceIndex) Owner(id string) (*Resource, bool) {
+	if i == nil {
+		return nil, false
+	}
+	for id = strings.ToLower(strings.TrimSuffix(id, "/")); id != ""; id = parentID(id) {
+		if r, ok := i.byID[id]; ok {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// Related returns the owners of the referenced ARM IDs, e.g. the network interfaces of a virtual machine.
+// Nil and unknown IDs are ignored and each owner is returned once.
+func (i *ResourceIndex) Related(ids ...*string) []*Resource {
+	related := []*Resource{}
+	seen := map[*Resource]bool{}
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+		if r, ok := i.Owner(*id); ok && !seen[r] {
+			seen[r] = true
+			related = append(related, r)
+		}
+	}
+	return related
+}
+
+// Children returns the nested resources of a resource, e.g. the databases of a SQL server.
+// All nested resources are returned if resourceType is empty.
+func (i *ResourceIndex) Children(id, resourceType string) []*Resource {
+	if i == nil {
+		return nil
+	}
+	return filterByType(i.byParent[strings.ToLower(strings.TrimSuffix(id, "/"))], resourceType)
+}
+
+// ByType returns the resources of a resource type
+func (i *ResourceIndex) ByType(resourceType string) []*Resource {
+	if i == nil {
+		return nil
+	}
+	return i.byType[strings.ToLower(resourceType)]
+}
+
+// InResourceGroup returns the resources of a resource group, filtered by resource type if not empty
+func (i *ResourceIndex) InResourceGroup(resourceGroupID, resourceType string) []*Resource {
+	if i == nil {
+		return nil
+	}
+	return filterByType(i.byResourceGroup[strings.ToLower(strings.TrimSuffix(resourceGroupID, "/"))], resourceType)
+}
+
+// DecodeProperties decodes the ARM properties of the resource, e.g. into an armnetwork.InterfacePropertiesFormat.
+// It returns false if the properties of the resource type are not indexed.
+func (r *Resource) DecodeProperties(v interface{}) bool {
+	if r == nil || r.Properties == nil {
+		return false
+	}
+	data, err := json.Marshal(r.Properties)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+func filterByType(resources []*Resource, resourceType string) []*Resource {
+	if resourceType == "" {
+		return resources
+	}
+	filtered := []*Resource{}
+	for _, r := range resources {
+		if strings.EqualFold(r.Type, resourceType) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// parentID returns the ID of the parent of a nested resource or sub resource,
+// e.g. the server of /subscriptions/x/resourceGroups/y/providers/Microsoft.Sql/servers/s/databases/d.
+// It returns an empty string for top level resources.
+func parentID(id string) string {
+	parts := strings.Split(id, "/")
+	providers := -1
+	for i := len(parts) - 1; i >= 0; i-- {
+		if strings.EqualFold(parts[i], "providers") {
+			providers = i
+			break
+		}
+	}
+
+	// providers, namespace, type and name of the top level resource
+	if providers < 0 || len(parts) < providers+6 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-2], "/")
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"testing"
+)
+
+func TestResourceIndex(t *testing.T) {
+	const rg = "/subscriptions/xxx/resourceGroups/rg"
+	server := &Resource{ID: rg + "/providers/Microsoft.Sql/servers/sql", Type: "Microsoft.Sql/servers"}
+	database := &Resource{ID: rg + "/providers/Microsoft.Sql/servers/sql/databases/db", Type: "Microsoft.Sql/servers/databases"}
+	vnet := &Resource{ID: rg + "/providers/Microsoft.Network/virtualNetworks/vnet", Type: "Microsoft.Network/virtualNetworks"}
+	nic := &Resource{ID: "/subscriptions/xxx/resourceGroups/other/providers/Microsoft.Network/networkInterfaces/nic", Type: "Microsoft.Network/networkInterfaces"}
+	index := NewResourceIndex([]*Resource{server, database, vnet}, []*Resource{nic})
+
+	subnet := rg + "/providers/Microsoft.Network/virtualNetworks/VNET/subnets/default"
+	ipConfig := nic.ID + "/ipConfigurations/ipconfig1"
+	unknown := rg + "/providers/Microsoft.Network/networkSecurityGroups/nsg"
+
+	tests := []struct {
+		name string
+		got  []*Resource
+		want []*Resource
+	}{
+		{name: "children", got: index.Children(server.ID, ""), want: []*Resource{database}},
+		{name: "children by type", got: index.Children(server.ID, "Microsoft.Sql/servers/elasticPools"), want: []*Resource{}},
+		{name: "by type", got: index.ByType("microsoft.network/virtualnetworks"), want: []*Resource{vnet}},
+		{name: "in resource group", got: index.InResourceGroup(rg, "Microsoft.Sql/servers"), want: []*Resource{server}},
+		{name: "related sub resources", got: index.Related(&subnet, &ipConfig, nil, &unknown, &subnet), want: []*Resource{vnet, nic}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.got) != len(tt.want) {
+				t.Fatalf("got %d resources, want %d", len(tt.got), len(tt.want))
+			}
+			for i := range tt.got {
+				if tt.got[i] != tt.want[i] {
+					t.Errorf("got %s, want %s", tt.got[i].ID, tt.want[i].ID)
+				}
+			}
+		})
+	}
+
+	if r, ok := index.Get(server.ID + "/"); !ok || r != server {
+		t.Errorf("Get() = (%v, %v), want %s", r, ok, server.ID)
+	}
+	if r, ok := index.Owner(subnet); !ok || r != vnet {
+		t.Errorf("Owner() = (%v, %v), want %s", r, ok, vnet.ID)
+	}
+	var empty *ResourceIndex
+	if _, ok := empty.Get(server.ID); ok || empty.Len() != 0 || len(empty.Related(&subnet)) != 0 {
+		t.Error("nil index must be empty")
+	}
+}
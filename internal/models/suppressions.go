@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SuppressTag is the tag listing the recommendations suppressed for a resource,
+// e.g. azqr-suppress: aks-004,aks-012;reason=approved-by-secops
+const SuppressTag = "azqr-suppress"
+
+// Suppression - Recommendations suppressed for a resource by its azqr-suppress tag
+type Suppression struct {
+	Recommendations map[string]bool
+	Reason          string
+}
+
+// ParseSuppression returns the suppression declared by the azqr-suppress tag, or nil if the tags do not have it.
+// The tag key is case insensitive, and so are the recommendation IDs.
+func ParseSuppression(tags map[string]*string) *Suppression {
+	for k, v := range tags {
+		if !strings.EqualFold(k, SuppressTag) || v == nil {
+			continue
+		}
+
+		s := &Suppression{Recommendations: map[string]bool{}}
+		parts := strings.Split(*v, ";")
+		for _, id := range strings.Split(parts[0], ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				s.Recommendations[strings.ToLower(id)] = true
+			}
+		}
+		for _, p := range parts[1:] {
+			key, value, ok := strings.Cut(p, "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "reason") {
+				s.Reason = strings.TrimSpace(value)
+			}
+		}
+		return s
+	}
+	return nil
+}
+
+// Suppresses returns true if the recommendation is suppressed
+func (s *Suppression) Suppresses(recommendationID string) bool {
+	return s != nil && s.Recommendations[strings.ToLower(recommendationID)]
+}
+
+// suppressionOf returns the suppression of a target with a Tags field, like the resources of the Azure SDK
+func suppressionOf(target interface{}) *Suppression {
+	v := indirect(reflect.ValueOf(target))
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.FieldByName("Tags")
+	if !f.IsValid() || !f.CanInterface() {
+		return nil
+	}
+	tags, _ := f.Interface().(map[string]*string)
+	return ParseSuppression(tags)
+}
+
+// SuppressAprlResults splits the APRL results in the ones reported and the ones suppressed
+// by the azqr-suppress tag of the resources in the inventory
+func SuppressAprlResults(results []AprlResult, resources *ResourceIndex) ([]AprlResult, []AprlResult) {
+	reported := []AprlResult{}
+	suppressed := []AprlResult{}
+	for _, r := range results {
+		resource, ok := resources.Get(r.ResourceID)
+		if !ok {
+			reported = append(reported, r)
+			continue
+		}
+
+		if suppression := ParseSuppression(resource.Tags); suppression.Suppresses(r.RecommendationID) {
+			r.SuppressionReason = suppression.Reason
+			suppressed = append(suppressed, r)
+			continue
+		}
+		reported = append(reported, r)
+	}
+	return reported, suppressed
+}
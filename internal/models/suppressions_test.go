@@ -0,0 +1,52 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"testing"
+)
+
+func TestParseSuppression(t *testing.T) {
+	tests := []struct {
+		name       string
+		tags       map[string]*string
+		id         string
+		suppressed bool
+		reason     string
+	}{
+		{name: "listed with reason", tags: map[string]*string{"azqr-suppress": ptr("aks-004,aks-012;reason=approved-by-secops")}, id: "aks-012", suppressed: true, reason: "approved-by-secops"},
+		{name: "case insensitive", tags: map[string]*string{"AZQR-Suppress": ptr(" AKS-004 ")}, id: "aks-004", suppressed: true, reason: ""},
+		{name: "not listed", tags: map[string]*string{"azqr-suppress": ptr("aks-004;reason=approved")}, id: "aks-001", suppressed: false, reason: "approved"},
+		{name: "without tag", tags: map[string]*string{"env": ptr("prd")}, id: "aks-004", suppressed: false, reason: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ParseSuppression(tt.tags)
+			if s.Suppresses(tt.id) != tt.suppressed {
+				t.Errorf("Suppresses(%s) = %v, want %v", tt.id, !tt.suppressed, tt.suppressed)
+			}
+			if s != nil && s.Reason != tt.reason {
+				t.Errorf("Reason = %s, want %s", s.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestSuppressAprlResults(t *testing.T) {
+	const id = "/subscriptions/xxx/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"
+	resources := NewResourceIndex([]*Resource{{ID: id, Tags: map[string]*string{"azqr-suppress": ptr("guid-1;reason=accepted")}}})
+
+	reported, suppressed := SuppressAprlResults([]AprlResult{
+		{RecommendationID: "guid-1", ResourceID: id},
+		{RecommendationID: "guid-2", ResourceID: id},
+		{RecommendationID: "guid-1", ResourceID: id + "2"},
+	}, resources)
+
+	if len(reported) != 2 || len(suppressed) != 1 {
+		t.Fatalf("got %d reported and %d suppressed, want 2 and 1", len(reported), len(suppressed))
+	}
+	if suppressed[0].RecommendationID != "guid-1" || suppressed[0].SuppressionReason != "accepted" {
+		t.Errorf("suppressed %s with reason %s, want guid-1 with reason accepted", suppressed[0].RecommendationID, suppressed[0].SuppressionReason)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type (
+	// TagPolicy - Tags required on resources and resource groups, replacing the "should have tags" check
+	TagPolicy struct {
+		Required []*RequiredTag `yaml:"required" json:"required"`
+
+		once sync.Once
+		err  error
+	}
+
+	// RequiredTag - Tag key required in the scopes, with optional allowed values or pattern
+	RequiredTag struct {
+		Key string `yaml:"key" json:"key"`
+		// Values allowed. Any value if empty.
+		Values []string `yaml:"values,flow" json:"values"`
+		// Pattern is a regular expression the value must match
+		Pattern string `yaml:"pattern" json:"pattern"`
+		// CaseSensitive compares keys, values and pattern case sensitively
+		CaseSensitive bool `yaml:"caseSensitive" json:"caseSensitive"`
+		// Inherit accepts the tag of the resource group when the resource does not have it
+		Inherit bool `yaml:"inherit" json:"inherit"`
+		// Scopes are the IDs of the subscriptions, resource groups or resources requiring the tag. All if empty.
+		Scopes []string `yaml:"scopes,flow" json:"scopes"`
+
+		regex *regexp.Regexp
+	}
+)
+
+// CheckTags returns true if the tags of a resource or resource group do not comply with the tag policy,
+// and the missing or invalid tags. Without a policy, at least one tag is required.
+func (c *ScanContext) CheckTags(id *string, tags map[string]*string) (bool, string) {
+	var policy *TagPolicy
+	if c != nil && c.Filters != nil && c.Filters.Azqr != nil {
+		policy = c.Filters.Azqr.Tags
+	}
+
+	if policy == nil || len(policy.Required) == 0 {
+		return len(tags) == 0, ""
+	}
+	if err := policy.compiled(); err != nil {
+		return true, err.Error()
+	}
+
+	resourceID := ""
+	if id != nil {
+		resourceID = *id
+	}
+
+	missing := []string{}
+	invalid := []string{}
+	for _, r := range policy.Required {
+		if !r.inScope(resourceID) {
+			continue
+		}
+
+		value, ok := r.lookup(tags)
+		if !ok && r.Inherit && c.ResourceGroupTags != nil {
+			value, ok = r.lookup(c.ResourceGroupTags[strings.ToLower(GetResourceGroupIDFromResourceID(resourceID))])
+		}
+
+		if !ok {
+			missing = append(missing, r.Key)
+		} else if !r.isValid(value) {
+			invalid = append(invalid, fmt.Sprintf("%s=%s", r.Key, value))
+		}
+	}
+
+	result := []string{}
+	if len(missing) > 0 {
+		result = append(result, "Missing: "+strings.Join(missing, ", "))
+	}
+	if len(invalid) > 0 {
+		result = append(result, "Invalid: "+strings.Join(invalid, ", "))
+	}
+	return len(result) > 0, strings.Join(result, "; ")
+}
+
+// Inherits returns true if any required tag can be inherited from resource groups
+func (p *TagPolicy) Inherits() bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Required {
+		if r.Inherit {
+			return true
+		}
+	}
+	return false
+}
+
+// compiled compiles the required tags once, also when the tag policy was not loaded from a filters file
+func (p *TagPolicy) compiled() error {
+	if p == nil {
+		return nil
+	}
+	p.once.Do(func() {
+		p.err = p.compile()
+	})
+	return p.err
+}
+
+// compile validates the required tags and compiles their patterns
+func (p *TagPolicy) compile() error {
+	if p == nil {
+		return nil
+	}
+
+	for _, r := range p.Required {
+		if r == nil || r.Key == "" {
+			return fmt.Errorf("required tag without key")
+		}
+		if r.Pattern == "" {
+			continue
+		}
+
+		pattern := r.Pattern
+		if !r.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern for tag %s: %w", r.Key, err)
+		}
+		r.regex = regex
+	}
+	return nil
+}
+
+func (r *RequiredTag) inScope(resourceID string) bool {
+	if len(r.Scopes) == 0 {
+		return true
+	}
+
+	id := strings.ToLower(resourceID)
+	for _, s := range r.Scopes {
+		scope := strings.ToLower(strings.TrimSuffix(s, "/"))
+		if id == scope || strings.HasPrefix(id, scope+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RequiredTag) lookup(tags map[string]*string) (string, bool) {
+	for k, v := range tags {
+		if k == r.Key || (!r.CaseSensitive && strings.EqualFold(k, r.Key)) {
+			if v == nil {
+				return "", true
+			}
+			return *v, true
+		}
+	}
+	return "", false
+}
+
+func (r *RequiredTag) isValid(value string) bool {
+	if r.regex != nil && !r.regex.MatchString(value) {
+		return false
+	}
+
+	if len(r.Values) == 0 {
+		return true
+	}
+	for _, v := range r.Values {
+		if v == value || (!r.CaseSensitive && strings.EqualFold(v, value)) {
+			return true
+		}
+	}
+	return false
+}
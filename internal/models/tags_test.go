@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"testing"
+)
+
+func TestScanContext_CheckTags(t *testing.T) {
+	policy := &TagPolicy{
+		Required: []*RequiredTag{
+			{Key: "CostCenter", Pattern: "^[0-9]{4}$", Inherit: true},
+			{Key: "Environment", Values: []string{"dev", "prod"}},
+			{Key: "Owner", CaseSensitive: true, Scopes: []string{"/subscriptions/sub/resourceGroups/app"}},
+		},
+	}
+	// not compiled: filters built in code, not loaded from a file, are compiled on first use
+	scanContext := &ScanContext{
+		Filters: &Filters{Azqr: &AzqrFilter{Tags: policy}},
+		ResourceGroupTags: map[string]map[string]*string{
+			"/subscriptions/sub/resourcegroups/shared": {"costcenter": ptr("1234")},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		scanContext *ScanContext
+		id          string
+		tags        map[string]*string
+		broken      bool
+		result      string
+	}{
+		{name: "any tag without policy", scanContext: &ScanContext{}, id: "/subscriptions/sub/resourceGroups/shared/providers/Microsoft.Web/sites/app", tags: map[string]*string{"a": ptr("b")}, broken: false, result: ""},
+		{name: "no tag without policy", scanContext: &ScanContext{}, id: "/subscriptions/sub/resourceGroups/shared/providers/Microsoft.Web/sites/app", broken: true, result: ""},
+		{name: "inherited tag, case insensitive key and value", scanContext: scanContext, id: "/subscriptions/sub/resourceGroups/shared/providers/Microsoft.Web/sites/app", tags: map[string]*string{"ENVIRONMENT": ptr("Prod")}, broken: false, result: ""},
+		{name: "missing tags", scanContext: scanContext, id: "/subscriptions/sub/resourceGroups/other/providers/Microsoft.Web/sites/app", broken: true, result: "Missing: CostCenter, Environment"},
+		{name: "invalid tags", scanContext: scanContext, id: "/subscriptions/sub/resourceGroups/other/providers/Microsoft.Web/sites/app", tags: map[string]*string{"CostCenter": ptr("abc"), "Environment": ptr("qa")}, broken: true, result: "Invalid: CostCenter=abc, Environment=qa"},
+		{name: "case sensitive key in scope", scanContext: scanContext, id: "/subscriptions/sub/resourceGroups/app/providers/Microsoft.Web/sites/app", tags: map[string]*string{"CostCenter": ptr("1234"), "Environment": ptr("dev"), "owner": ptr("me")}, broken: true, result: "Missing: Owner"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broken, result := tt.scanContext.CheckTags(&tt.id, tt.tags)
+			if broken != tt.broken || result != tt.result {
+				t.Errorf("CheckTags() = (%v, %s), want (%v, %s)", broken, result, tt.broken, tt.result)
+			}
+		})
+	}
+}
+
+func TestScanContext_CheckTags_InvalidPolicy(t *testing.T) {
+	id := "/subscriptions/sub/resourceGroups/app"
+	for _, policy := range []*TagPolicy{
+		{Required: []*RequiredTag{{Pattern: "^[0-9]+$"}}},
+		{Required: []*RequiredTag{{Key: "CostCenter", Pattern: "^[0-9"}}},
+	} {
+		scanContext := &ScanContext{Filters: &Filters{Azqr: &AzqrFilter{Tags: policy}}}
+		if broken, result := scanContext.CheckTags(&id, map[string]*string{"CostCenter": ptr("1234")}); !broken || result == "" {
+			t.Errorf("CheckTags() = (%v, %s), want the error of the invalid policy", broken, result)
+		}
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
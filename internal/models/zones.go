@@ -0,0 +1,58 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package models
+
+import "strings"
+
+// zonalRegions are the regions with availability zones
+// https://learn.microsoft.com/en-us/azure/reliability/availability-zones-region-support
+var zonalRegions = map[string]bool{
+	"australiaeast":      true,
+	"austriaeast":        true,
+	"brazilsouth":        true,
+	"canadacentral":      true,
+	"centralindia":       true,
+	"centralus":          true,
+	"chilecentral":       true,
+	"chinanorth3":        true,
+	"eastasia":           true,
+	"eastus":             true,
+	"eastus2":            true,
+	"francecentral":      true,
+	"germanywestcentral": true,
+	"indonesiacentral":   true,
+	"israelcentral":      true,
+	"italynorth":         true,
+	"japaneast":          true,
+	"japanwest":          true,
+	"koreacentral":       true,
+	"malaysiawest":       true,
+	"mexicocentral":      true,
+	"newzealandnorth":    true,
+	"northeurope":        true,
+	"norwayeast":         true,
+	"polandcentral":      true,
+	"qatarcentral":       true,
+	"southafricanorth":   true,
+	"southcentralus":     true,
+	"southeastasia":      true,
+	"spaincentral":       true,
+	"swedencentral":      true,
+	"switzerlandnorth":   true,
+	"uaenorth":           true,
+	"uksouth":            true,
+	"usgovvirginia":      true,
+	"westeurope":         true,
+	"westus2":            true,
+	"westus3":            true,
+}
+
+// HasAvailabilityZones returns false if the location is a region without availability zones.
+// Resources without location are assumed to be in a region with zones.
+func HasAvailabilityZones(location *string) bool {
+	if location == nil || *location == "" {
+		return true
+	}
+	return zonalRegions[strings.ToLower(strings.ReplaceAll(*location, " ", ""))]
+}
@@ -0,0 +1,195 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type (
+	// Interaction - A recorded HTTP request and its response
+	Interaction struct {
+		Request  RecordedRequest  `json:"request"`
+		Response RecordedResponse `json:"response"`
+	}
+
+	// RecordedRequest - A scrubbed HTTP request
+	RecordedRequest struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+		Body   string `json:"body,omitempty"`
+	}
+
+	// RecordedResponse - A scrubbed HTTP response
+	RecordedResponse struct {
+		StatusCode int               `json:"statusCode"`
+		Headers    map[string]string `json:"headers,omitempty"`
+		Body       string            `json:"body,omitempty"`
+	}
+
+	// Recorder is a transport that sends requests with the next transport and appends every exchange,
+	// scrubbed of secrets and IDs, to a fixture bundle. The bundle is a JSON Lines file written as requests
+	// complete, so an interrupted scan still leaves a usable recording.
+	Recorder struct {
+		next     policy.Transporter
+		mu       sync.Mutex
+		file     *os.File
+		scrubber *scrubber
+	}
+
+	// scrubber replaces GUIDs with stable fake GUIDs and removes secrets
+	scrubber struct {
+		ids map[string]string
+	}
+)
+
+var (
+	guidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+	// recommendationPattern matches the recommendation IDs of Resource Graph queries and results, which are public and kept
+	recommendationPattern = regexp.MustCompile(`(?i)recommendationId(?:\\?")?\s*[:=]\s*\\?"(` + guidPattern.String() + `)`)
+
+	secretPattern = regexp.MustCompile(`(?i)"([a-z_]*(?:key|secret|password|connectionstring|sastoken|accesstoken|access_token|refresh_token))"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	// secretFieldExceptions are fields matching secretPattern that hold no secrets and are used by the scanners
+	secretFieldExceptions = map[string]bool{
+		"keysource":    true,
+		"keytype":      true,
+		"keyname":      true,
+		"partitionkey": true,
+	}
+
+	// recordedHeaders are the response headers kept in the bundle
+	recordedHeaders = []string{
+		"Content-Type",
+		"Retry-After",
+		"x-ms-ratelimit-remaining-subscription-reads",
+		"x-ms-ratelimit-remaining-tenant-reads",
+		"x-ms-user-quota-remaining",
+		"x-ms-user-quota-resets-after",
+	}
+)
+
+// NewRecorder creates a recorder writing to path. If next is nil, http.DefaultClient is used.
+func NewRecorder(path string, next policy.Transporter) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultClient
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording %s: %w", path, err)
+	}
+
+	return &Recorder{
+		next:     next,
+		file:     file,
+		scrubber: &scrubber{ids: map[string]string{}},
+	}, nil
+}
+
+// Do sends the request and records the exchange
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.next.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    r.scrubber.scrub(req.URL.String()),
+			Body:   r.scrubber.scrub(string(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    map[string]string{},
+			Body:       r.scrubber.scrub(string(respBody)),
+		},
+	}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			interaction.Response.Headers[h] = v
+		}
+	}
+
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recorded interaction: %w", err)
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write recorded interaction: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Close closes the recording file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// scrub replaces every GUID (subscription, tenant, principal IDs...) with a fake GUID and removes secrets.
+// The same GUID is always replaced with the same fake GUID, so IDs still match across requests.
+// Recommendation IDs are kept, so replayed queries and results match the embedded recommendations.
+func (s *scrubber) scrub(value string) string {
+	public := map[string]bool{}
+	for _, m := range recommendationPattern.FindAllStringSubmatch(value, -1) {
+		public[strings.ToLower(m[1])] = true
+	}
+
+	value = guidPattern.ReplaceAllStringFunc(value, func(id string) string {
+		if public[strings.ToLower(id)] {
+			return id
+		}
+		id = strings.ToLower(id)
+		fake, ok := s.ids[id]
+		if !ok {
+			fake = fmt.Sprintf("00000000-0000-0000-0000-%012d", len(s.ids)+1)
+			s.ids[id] = fake
+		}
+		return fake
+	})
+
+	return secretPattern.ReplaceAllStringFunc(value, func(field string) string {
+		m := secretPattern.FindStringSubmatch(field)
+		if secretFieldExceptions[strings.ToLower(m[1])] {
+			return field
+		}
+		return fmt.Sprintf(`"%s"%s"REDACTED"`, m[1], m[2])
+	})
+}
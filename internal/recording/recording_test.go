@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package recording
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_Replay(t *testing.T) {
+	const subscriptionID = "0b6fa5b2-4a0e-4e4b-9d8f-3c2d1e0f9a8b"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("x-ms-ratelimit-remaining-subscription-reads", "249")
+		_, _ = w.Write([]byte(`{"id":"/subscriptions/` + subscriptionID + `","primaryKey":"c2VjcmV0","keySource":"Microsoft.Storage"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+	recorder, err := NewRecorder(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/subscriptions/"+subscriptionID, nil)
+	resp, err := recorder.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), subscriptionID) {
+		t.Errorf("Recorder.Do() must return the original response, got %s", body)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const fakeID = "00000000-0000-0000-0000-000000000001"
+	tests := []struct {
+		name     string
+		url      string
+		wantErr  bool
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "scrubbed request is replayed",
+			url:      server.URL + "/subscriptions/" + fakeID,
+			contains: []string{fakeID, `"primaryKey":"REDACTED"`, `"keySource":"Microsoft.Storage"`},
+			excludes: []string{subscriptionID, "c2VjcmV0"},
+		},
+		{
+			name:    "unknown request fails",
+			url:     server.URL + "/subscriptions/" + subscriptionID,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			resp, err := replayer.Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Replayer.Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			body, _ := io.ReadAll(resp.Body)
+			for _, c := range tt.contains {
+				if !strings.Contains(string(body), c) {
+					t.Errorf("Replayer.Do() body = %s, must contain %s", body, c)
+				}
+			}
+			for _, e := range tt.excludes {
+				if strings.Contains(string(body), e) {
+					t.Errorf("Replayer.Do() body = %s, must not contain %s", body, e)
+				}
+			}
+			if got := resp.Header.Get("x-ms-ratelimit-remaining-subscription-reads"); got != "249" {
+				t.Errorf("Replayer.Do() remaining reads header = %s, want 249", got)
+			}
+		})
+	}
+}
+
+func TestScrubber_RecommendationIDs(t *testing.T) {
+	const (
+		subscriptionID   = "0b6fa5b2-4a0e-4e4b-9d8f-3c2d1e0f9a8b"
+		recommendationID = "5E6F7A8B-9C0D-1E2F-3A4B-5C6D7E8F9A0B"
+	)
+	s := &scrubber{ids: map[string]string{}}
+
+	// recommendation IDs of queries and results are public and kept, other GUIDs are replaced
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "query",
+			value: `{"subscriptions":["` + subscriptionID + `"],"query":"resources | project recommendationId=\"` + recommendationID + `\", id"}`,
+			want:  `{"subscriptions":["00000000-0000-0000-0000-000000000001"],"query":"resources | project recommendationId=\"` + recommendationID + `\", id"}`,
+		},
+		{
+			name:  "result",
+			value: `{"data":[{"recommendationId":"` + recommendationID + `","id":"/subscriptions/` + subscriptionID + `"}]}`,
+			want:  `{"data":[{"recommendationId":"` + recommendationID + `","id":"/subscriptions/00000000-0000-0000-0000-000000000001"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.scrub(tt.value); got != tt.want {
+				t.Errorf("scrub() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type (
+	// Replayer is a transport that serves the responses of a fixture bundle created by a Recorder,
+	// without sending any request over the network.
+	Replayer struct {
+		mu           sync.Mutex
+		interactions map[string][]*replayedInteraction
+	}
+
+	replayedInteraction struct {
+		Interaction
+		body string
+		used bool
+	}
+
+	// FakeCredential is a credential returning a static token, used to replay scans without signing in
+	FakeCredential struct{}
+)
+
+// NewReplayer loads the fixture bundle at path
+func NewReplayer(path string) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %s: %w", path, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	r := &Replayer{
+		interactions: map[string][]*replayedInteraction{},
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 256*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var i Interaction
+		if err := json.Unmarshal(line, &i); err != nil {
+			return nil, fmt.Errorf("failed to parse recording %s: %w", path, err)
+		}
+		key := requestKey(i.Request.Method, i.Request.URL)
+		r.interactions[key] = append(r.interactions[key], &replayedInteraction{
+			Interaction: i,
+			body:        canonicalBody(i.Request.Body),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %w", path, err)
+	}
+
+	return r, nil
+}
+
+// Do returns the recorded response matching the request method, URL and body.
+// Identical requests are served in recorded order; once exhausted, the last response is served again.
+func (r *Replayer) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	interaction := r.match(req.Method, req.URL.String(), canonicalBody(string(body)))
+	if interaction == nil {
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL.String())
+	}
+
+	resp := &http.Response{
+		StatusCode:    interaction.Response.StatusCode,
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		Header:        http.Header{},
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}
+	for k, v := range interaction.Response.Headers {
+		resp.Header.Set(k, v)
+	}
+	return resp, nil
+}
+
+func (r *Replayer) match(method, url, body string) *replayedInteraction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	candidates := r.interactions[requestKey(method, url)]
+
+	// prefer a request with the same body, then any request to the same URL, since some
+	// bodies change between runs (e.g. the time period of a cost query)
+	for _, sameBody := range []bool{true, false} {
+		var last *replayedInteraction
+		for _, c := range candidates {
+			if sameBody && c.body != body {
+				continue
+			}
+			last = c
+			if !c.used {
+				c.used = true
+				return c
+			}
+		}
+		if last != nil {
+			return last
+		}
+	}
+	return nil
+}
+
+func requestKey(method, url string) string {
+	return method + " " + strings.ToLower(url)
+}
+
+// canonicalBody returns a JSON body with its string arrays sorted, so requests built from map iterations
+// (e.g. the subscriptions of a Resource Graph query) match regardless of their order.
+func canonicalBody(body string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+	b, err := json.Marshal(sortStrings(v))
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
+
+func sortStrings(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = sortStrings(e)
+		}
+	case []interface{}:
+		values := []string{}
+		for i, e := range t {
+			t[i] = sortStrings(e)
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		if len(values) == len(t) {
+			sort.Strings(values)
+			for i, s := range values {
+				t[i] = s
+			}
+		}
+	}
+	return v
+}
+
+// GetToken returns a static token that never expires during a replay
+func (c FakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "replay", ExpiresOn: time.Now().Add(24 * time.Hour)}, nil
+}
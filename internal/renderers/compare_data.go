@@ -0,0 +1,150 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"fmt"
+
+	"github.com/Azure/azqr/internal/models"
+)
+
+type (
+	// CompareData - Differences between two scans
+	CompareData struct {
+		OutputFileName string
+		// Before and After are the scan results compared
+		Before, After    string
+		NewFindings      []models.Finding
+		ResolvedFindings []models.Finding
+		AddedResources   []CompareResource
+		RemovedResources []CompareResource
+		SLAChanges       []SLAChange
+		CostDeltas       []CostDelta
+	}
+
+	// CompareResource - Resource of the inventory of a scan
+	CompareResource struct {
+		ResourceID   string
+		ResourceType string
+		Name         string
+		SLA          string
+	}
+
+	// SLAChange - Change of the SLA of a resource between two scans
+	SLAChange struct {
+		Resource CompareResource
+		Before   string
+	}
+
+	// CostDelta - Change of the cost of a service between two scans
+	CostDelta struct {
+		ServiceName   string
+		Currency      string
+		Before, After float64
+	}
+)
+
+// CompareTable - Table of a comparison and the name of the sheet or file it is rendered to
+type CompareTable struct {
+	Name string
+	Data [][]string
+}
+
+// Tables returns all tables of the comparison
+func (cd *CompareData) Tables() []CompareTable {
+	return []CompareTable{
+		{"NewFindings", cd.FindingsTable(cd.NewFindings)},
+		{"ResolvedFindings", cd.FindingsTable(cd.ResolvedFindings)},
+		{"AddedResources", cd.ResourcesTable(cd.AddedResources)},
+		{"RemovedResources", cd.ResourcesTable(cd.RemovedResources)},
+		{"SLAChanges", cd.SLAChangesTable()},
+		{"CostDeltas", cd.CostDeltasTable()},
+	}
+}
+
+// SummaryTable counts the differences between the scans
+func (cd *CompareData) SummaryTable() [][]string {
+	return [][]string{
+		{"Before", "After", "New Findings", "Resolved Findings", "Added Resources", "Removed Resources", "SLA Changes"},
+		{
+			cd.Before,
+			cd.After,
+			fmt.Sprint(len(cd.NewFindings)),
+			fmt.Sprint(len(cd.ResolvedFindings)),
+			fmt.Sprint(len(cd.AddedResources)),
+			fmt.Sprint(len(cd.RemovedResources)),
+			fmt.Sprint(len(cd.SLAChanges)),
+		},
+	}
+}
+
+func (cd *CompareData) FindingsTable(findings []models.Finding) [][]string {
+	headers := []string{"Source", "Recommendation Id", "Recommendation", "Resource Id"}
+	rows := [][]string{}
+	for _, f := range findings {
+		row := []string{
+			string(f.Source),
+			f.RecommendationID,
+			f.Recommendation,
+			f.ResourceID,
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
+func (cd *CompareData) ResourcesTable(resources []CompareResource) [][]string {
+	headers := []string{"Resource Type", "Resource Name", "SLA", "Resource Id"}
+	rows := [][]string{}
+	for _, r := range resources {
+		row := []string{
+			r.ResourceType,
+			r.Name,
+			r.SLA,
+			r.ResourceID,
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
+func (cd *CompareData) SLAChangesTable() [][]string {
+	headers := []string{"Resource Type", "Resource Name", "SLA Before", "SLA After", "Resource Id"}
+	rows := [][]string{}
+	for _, c := range cd.SLAChanges {
+		row := []string{
+			c.Resource.ResourceType,
+			c.Resource.Name,
+			c.Before,
+			c.Resource.SLA,
+			c.Resource.ResourceID,
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
+func (cd *CompareData) CostDeltasTable() [][]string {
+	headers := []string{"Service Name", "Cost Before", "Cost After", "Delta", "Currency"}
+	rows := [][]string{}
+	for _, c := range cd.CostDeltas {
+		row := []string{
+			c.ServiceName,
+			fmt.Sprintf("%.2f", c.Before),
+			fmt.Sprintf("%.2f", c.After),
+			fmt.Sprintf("%+.2f", c.After-c.Before),
+			c.Currency,
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
@@ -13,48 +13,60 @@ import (
 	"github.com/Azure/azqr/internal/renderers"
 )
 
-func CreateCsvReport(data *renderers.ReportData) {
-	records := data.RecommendationsTable()
-	writeData(records, data.OutputFileName, "recommendations")
-
-	records = data.ImpactedTable()
-	writeData(records, data.OutputFileName, "impacted")
-
-	records = data.ResourceTypesTable()
-	writeData(records, data.OutputFileName, "resourceType")
-
-	records = data.ResourcesTable()
-	writeData(records, data.OutputFileName, "inventory")
-
-	records = data.DefenderTable()
-	writeData(records, data.OutputFileName, "defender")
-
-	records = data.DefenderRecommendationsTable()
-	writeData(records, data.OutputFileName, "defenderRecommendations")
-
-	records = data.AdvisorTable()
-	writeData(records, data.OutputFileName, "advisor")
+func CreateCsvReport(data *renderers.ReportData) error {
+	tables := []struct {
+		data      [][]string
+		extension string
+	}{
+		{data.RecommendationsTable(), "recommendations"},
+		{data.ImpactedTable(), "impacted"},
+		{data.SuppressedTable(), "suppressed"},
+		{data.ResourceTypesTable(), "resourceType"},
+		{data.ResourcesTable(), "inventory"},
+		{data.DefenderTable(), "defender"},
+		{data.DefenderRecommendationsTable(), "defenderRecommendations"},
+		{data.AdvisorTable(), "advisor"},
+		{data.CostTable(), "costs"},
+		{data.ExcludedResourcesTable(), "outofscope"},
+		{data.ErrorsTable(), "errors"},
+		{data.ExceptionsTable(), "exceptions"},
+	}
 
-	records = data.CostTable()
-	writeData(records, data.OutputFileName, "costs")
+	// the baseline table is only written if the scan was compared to a baseline
+	if data.Baseline != nil {
+		tables = append(tables, struct {
+			data      [][]string
+			extension string
+		}{data.BaselineTable(), "baseline"})
+	}
 
-	records = data.ExcludedResourcesTable()
-	writeData(records, data.OutputFileName, "outofscope")
+	for _, t := range tables {
+		if err := writeData(t.data, data.OutputFileName, t.extension); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func writeData(data [][]string, fileName, extension string) {
+func writeData(data [][]string, fileName, extension string) error {
 	filename := fmt.Sprintf("%s.%s.csv", fileName, extension)
 	log.Info().Msgf("Generating Report: %s", filename)
 
 	f, err := os.Create(filename)
 	if err != nil {
-		log.Fatal().Err(err).Msg("error creating csv:")
+		return fmt.Errorf("error creating csv: %w", err)
 	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil {
+			log.Warn().Err(cerr).Msg("error closing file:")
+		}
+	}()
 
 	w := csv.NewWriter(f)
 	err = w.WriteAll(data) // calls Flush internally
 
 	if err != nil {
-		log.Fatal().Err(w.Error()).Msg("error writing csv:")
+		return fmt.Errorf("error writing csv: %w", err)
 	}
+	return nil
 }
@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package excel
+
+import (
+	"fmt"
+	_ "image/png"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
+	"github.com/xuri/excelize/v2"
+)
+
+// renderBaseline renders the new, existing and resolved findings compared to the baseline.
+// The sheet is only created if the scan was compared to a baseline.
+func renderBaseline(f *excelize.File, data *renderers.ReportData) error {
+	if data.Baseline == nil {
+		return nil
+	}
+
+	sheetName := "Baseline"
+	_, err := f.NewSheet(sheetName)
+	if err != nil {
+		return fmt.Errorf("failed to create %s sheet: %w", sheetName, err)
+	}
+
+	records := data.BaselineTable()
+	headers := records[0]
+	if err := createFirstRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	if len(records) > 1 {
+		records = records[1:]
+		currentRow := 4
+		for _, row := range records {
+			currentRow += 1
+			cell, err := excelize.CoordinatesToCellName(1, currentRow)
+			if err != nil {
+				return fmt.Errorf("failed to get cell: %w", err)
+			}
+			err = f.SetSheetRow(sheetName, cell, &row)
+			if err != nil {
+				return fmt.Errorf("failed to set row: %w", err)
+			}
+		}
+
+		if err := configureSheet(f, sheetName, headers, currentRow); err != nil {
+			return err
+		}
+	} else {
+		log.Info().Msgf("Skipping %s. No data to render", sheetName)
+	}
+
+	return nil
+}
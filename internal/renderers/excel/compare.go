@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package excel
+
+import (
+	"fmt"
+	_ "image/png"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
+	"github.com/xuri/excelize/v2"
+)
+
+// CreateCompareReport writes the differences between two scans to <OutputFileName>.xlsx
+func CreateCompareReport(data *renderers.CompareData) error {
+	filename := fmt.Sprintf("%s.xlsx", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close Excel file")
+		}
+	}()
+
+	if err := f.SetSheetName("Sheet1", "Summary"); err != nil {
+		return fmt.Errorf("failed to create Summary sheet: %w", err)
+	}
+	if err := renderTable(f, "Summary", data.SummaryTable()); err != nil {
+		return err
+	}
+
+	for _, t := range data.Tables() {
+		if _, err := f.NewSheet(t.Name); err != nil {
+			return fmt.Errorf("failed to create %s sheet: %w", t.Name, err)
+		}
+		if err := renderTable(f, t.Name, t.Data); err != nil {
+			return err
+		}
+	}
+
+	if err := f.SaveAs(filename); err != nil {
+		return fmt.Errorf("failed to save Excel file: %w", err)
+	}
+	return nil
+}
+
+// renderTable renders the headers and rows of a table to an existing sheet
+func renderTable(f *excelize.File, sheetName string, records [][]string) error {
+	headers := records[0]
+	if err := createFirstRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	if len(records) > 1 {
+		records = records[1:]
+		currentRow := 4
+		for _, row := range records {
+			currentRow += 1
+			cell, err := excelize.CoordinatesToCellName(1, currentRow)
+			if err != nil {
+				return fmt.Errorf("failed to get cell: %w", err)
+			}
+			err = f.SetSheetRow(sheetName, cell, &row)
+			if err != nil {
+				return fmt.Errorf("failed to set row: %w", err)
+			}
+		}
+
+		if err := configureSheet(f, sheetName, headers, currentRow); err != nil {
+			return err
+		}
+	} else {
+		log.Info().Msgf("Skipping %s. No data to render", sheetName)
+	}
+
+	return nil
+}
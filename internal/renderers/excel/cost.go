@@ -4,6 +4,7 @@
 package excel
 
 import (
+	"fmt"
 	_ "image/png"
 
 	"github.com/Azure/azqr/internal/renderers"
@@ -11,15 +12,17 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
-func renderCosts(f *excelize.File, data *renderers.ReportData) {
+func renderCosts(f *excelize.File, data *renderers.ReportData) error {
 	_, err := f.NewSheet("Costs")
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create Costs sheet")
+		return fmt.Errorf("failed to create Costs sheet: %w", err)
 	}
 
 	records := data.CostTable()
 	headers := records[0]
-	createFirstRow(f, "Costs", headers)
+	if err := createFirstRow(f, "Costs", headers); err != nil {
+		return err
+	}
 
 	if data.Cost != nil && len(data.Cost.Items) > 0 {
 		records = records[1:]
@@ -28,16 +31,20 @@ func renderCosts(f *excelize.File, data *renderers.ReportData) {
 			currentRow += 1
 			cell, err := excelize.CoordinatesToCellName(1, currentRow)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to get cell")
+				return fmt.Errorf("failed to get cell: %w", err)
 			}
 			err = f.SetSheetRow("Costs", cell, &row)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to set row")
+				return fmt.Errorf("failed to set row: %w", err)
 			}
 		}
 
-		configureSheet(f, "Costs", headers, currentRow)
+		if err := configureSheet(f, "Costs", headers, currentRow); err != nil {
+			return err
+		}
 	} else {
 		log.Info().Msg("Skipping Costs. No data to render")
 	}
+
+	return nil
 }
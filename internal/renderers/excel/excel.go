@@ -13,30 +13,49 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
-func CreateExcelReport(data *renderers.ReportData) {
+func CreateExcelReport(data *renderers.ReportData) error {
 	filename := fmt.Sprintf("%s.xlsx", data.OutputFileName)
 	log.Info().Msgf("Generating Report: %s", filename)
 	f := excelize.NewFile()
 	defer func() {
 		if err := f.Close(); err != nil {
-			log.Fatal().Err(err).Msg("Failed to close Excel file")
+			log.Warn().Err(err).Msg("Failed to close Excel file")
 		}
 	}()
 
-	lastRow := renderRecommendations(f, data)
-	renderImpactedResources(f, data)
-	renderResourceTypes(f, data)
-	renderResources(f, data)
-	renderAdvisor(f, data)
-	renderDefenderRecommendations(f, data)
-	renderExcludedResources(f, data)
-	renderDefender(f, data)
-	renderCosts(f, data)
-	renderRecommendationsPivotTables(f, lastRow)
+	lastRow, err := renderRecommendations(f, data)
+	if err != nil {
+		return err
+	}
+
+	sheets := []func(*excelize.File, *renderers.ReportData) error{
+		renderImpactedResources,
+		renderSuppressed,
+		renderBaseline,
+		renderResourceTypes,
+		renderResources,
+		renderAdvisor,
+		renderDefenderRecommendations,
+		renderExcludedResources,
+		renderDefender,
+		renderCosts,
+		renderScanErrors,
+		renderExceptions,
+	}
+	for _, render := range sheets {
+		if err := render(f, data); err != nil {
+			return err
+		}
+	}
+
+	if err := renderRecommendationsPivotTables(f, lastRow); err != nil {
+		return err
+	}
 
 	if err := f.SaveAs(filename); err != nil {
-		log.Fatal().Err(err).Msg("Failed to save Excel file")
+		return fmt.Errorf("failed to save Excel file: %w", err)
 	}
+	return nil
 }
 
 func autofit(f *excelize.File, sheetName string) error {
@@ -69,15 +88,15 @@ func autofit(f *excelize.File, sheetName string) error {
 	return nil
 }
 
-func createFirstRow(f *excelize.File, sheet string, headers []string) {
+func createFirstRow(f *excelize.File, sheet string, headers []string) error {
 	currentRow := 4
 	cell, err := excelize.CoordinatesToCellName(1, currentRow)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get cell")
+		return fmt.Errorf("failed to get cell: %w", err)
 	}
 	err = f.SetSheetRow(sheet, cell, &headers)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to set row")
+		return fmt.Errorf("failed to set row: %w", err)
 	}
 
 	style, err := f.NewStyle(&excelize.Style{
@@ -92,20 +111,21 @@ func createFirstRow(f *excelize.File, sheet string, headers []string) {
 	})
 
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create style")
+		return fmt.Errorf("failed to create style: %w", err)
 	}
 
 	for j := 1; j <= len(headers); j++ {
 		cell, err := excelize.CoordinatesToCellName(j, 4)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to get cell")
+			return fmt.Errorf("failed to get cell: %w", err)
 		}
 
 		err = f.SetCellStyle(sheet, cell, cell, style)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to set style")
+			return fmt.Errorf("failed to set style: %w", err)
 		}
 	}
+	return nil
 }
 
 func setHyperLink(f *excelize.File, sheet string, col, currentRow int) {
@@ -119,16 +139,16 @@ func setHyperLink(f *excelize.File, sheet string, col, currentRow int) {
 	}
 }
 
-func configureSheet(f *excelize.File, sheet string, headers []string, currentRow int) {
+func configureSheet(f *excelize.File, sheet string, headers []string, currentRow int) error {
 	_ = autofit(f, sheet)
 
 	cell, err := excelize.CoordinatesToCellName(len(headers), currentRow)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get cell")
+		return fmt.Errorf("failed to get cell: %w", err)
 	}
 	err = f.AutoFilter(sheet, fmt.Sprintf("A4:%s", cell), nil)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to set autofilter")
+		return fmt.Errorf("failed to set autofilter: %w", err)
 	}
 
 	logo := embeded.GetTemplates("azqr.png")
@@ -145,13 +165,13 @@ func configureSheet(f *excelize.File, sheet string, headers []string, currentRow
 	}
 
 	if err := f.AddPictureFromBytes(sheet, "A1", pic); err != nil {
-		log.Fatal().Err(err).Msg("Failed to add logo")
+		return fmt.Errorf("failed to add logo: %w", err)
 	}
 
-	applyBlueStyle(f, sheet, currentRow, len(headers))
+	return applyBlueStyle(f, sheet, currentRow, len(headers))
 }
 
-func applyBlueStyle(f *excelize.File, sheet string, lastRow int, columns int) {
+func applyBlueStyle(f *excelize.File, sheet string, lastRow int, columns int) error {
 	blue, err := f.NewStyle(&excelize.Style{
 		Fill: excelize.Fill{
 			Type:    "pattern",
@@ -164,7 +184,7 @@ func applyBlueStyle(f *excelize.File, sheet string, lastRow int, columns int) {
 		},
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create blue style")
+		return fmt.Errorf("failed to create blue style: %w", err)
 	}
 	white, err := f.NewStyle(&excelize.Style{
 		Alignment: &excelize.Alignment{
@@ -173,27 +193,25 @@ func applyBlueStyle(f *excelize.File, sheet string, lastRow int, columns int) {
 		},
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create blue style")
+		return fmt.Errorf("failed to create white style: %w", err)
 	}
 
 	for i := 5; i <= lastRow; i++ {
 		for j := 1; j <= columns; j++ {
 			cell, err := excelize.CoordinatesToCellName(j, i)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to get cell")
+				return fmt.Errorf("failed to get cell: %w", err)
 			}
 
+			style := white
 			if i%2 == 0 {
-				err = f.SetCellStyle(sheet, cell, cell, blue)
-				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to set style")
-				}
-			} else {
-				err = f.SetCellStyle(sheet, cell, cell, white)
-				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to set style")
-				}
+				style = blue
+			}
+			err = f.SetCellStyle(sheet, cell, cell, style)
+			if err != nil {
+				return fmt.Errorf("failed to set style: %w", err)
 			}
 		}
 	}
+	return nil
 }
@@ -4,6 +4,7 @@
 package excel
 
 import (
+	"fmt"
 	_ "image/png"
 
 	"github.com/Azure/azqr/internal/renderers"
@@ -11,23 +12,25 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
-func renderResources(f *excelize.File, data *renderers.ReportData) {
-	createResourcesSheet(f, "Inventory", data.ResourcesTable())
+func renderResources(f *excelize.File, data *renderers.ReportData) error {
+	return createResourcesSheet(f, "Inventory", data.ResourcesTable())
 }
 
-func renderExcludedResources(f *excelize.File, data *renderers.ReportData) {
-	createResourcesSheet(f, "OutOfScope", data.ExcludedResourcesTable())
+func renderExcludedResources(f *excelize.File, data *renderers.ReportData) error {
+	return createResourcesSheet(f, "OutOfScope", data.ExcludedResourcesTable())
 }
 
-func createResourcesSheet(f *excelize.File, sheetName string, table [][]string) {
+func createResourcesSheet(f *excelize.File, sheetName string, table [][]string) error {
 	_, err := f.NewSheet(sheetName)
 	if err != nil {
-		log.Fatal().Err(err).Msgf("Failed to create %s sheet", sheetName)
+		return fmt.Errorf("failed to create %s sheet: %w", sheetName, err)
 	}
 
 	records := table
 	headers := records[0]
-	createFirstRow(f, sheetName, headers)
+	if err := createFirstRow(f, sheetName, headers); err != nil {
+		return err
+	}
 
 	if len(table) > 0 {
 		records = records[1:]
@@ -36,17 +39,21 @@ func createResourcesSheet(f *excelize.File, sheetName string, table [][]string)
 			currentRow += 1
 			cell, err := excelize.CoordinatesToCellName(1, currentRow)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to get cell")
+				return fmt.Errorf("failed to get cell: %w", err)
 			}
 			err = f.SetSheetRow(sheetName, cell, &row)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to set row")
+				return fmt.Errorf("failed to set row: %w", err)
 			}
 			setHyperLink(f, sheetName, 12, currentRow)
 		}
 
-		configureSheet(f, sheetName, headers, currentRow)
+		if err := configureSheet(f, sheetName, headers, currentRow); err != nil {
+			return err
+		}
 	} else {
 		log.Info().Msg("Skipping Services. No data to render")
 	}
+
+	return nil
 }
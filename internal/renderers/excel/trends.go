@@ -0,0 +1,122 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package excel
+
+import (
+	"fmt"
+	_ "image/png"
+	"strconv"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
+	"github.com/xuri/excelize/v2"
+)
+
+// CreateTrendsReport writes the weekly trends of the scans to <OutputFileName>.xlsx
+func CreateTrendsReport(data *renderers.TrendsData) error {
+	filename := fmt.Sprintf("%s.xlsx", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close Excel file")
+		}
+	}()
+
+	if err := f.SetSheetName("Sheet1", "HighImpactFindings"); err != nil {
+		return fmt.Errorf("failed to create HighImpactFindings sheet: %w", err)
+	}
+	if err := renderTrend(f, "HighImpactFindings", "Open High Impact Findings", data.HighImpactTable()); err != nil {
+		return err
+	}
+
+	if _, err := f.NewSheet("DefenderCoverage"); err != nil {
+		return fmt.Errorf("failed to create DefenderCoverage sheet: %w", err)
+	}
+	if err := renderTrend(f, "DefenderCoverage", "Defender Plans Enabled (%)", data.DefenderCoverageTable()); err != nil {
+		return err
+	}
+
+	if _, err := f.NewSheet("Findings"); err != nil {
+		return fmt.Errorf("failed to create Findings sheet: %w", err)
+	}
+	if err := renderTable(f, "Findings", data.FindingsTable()); err != nil {
+		return err
+	}
+
+	if err := f.SaveAs(filename); err != nil {
+		return fmt.Errorf("failed to save Excel file: %w", err)
+	}
+	return nil
+}
+
+// renderTrend renders a table with a row per week and a column per subscription, and its line chart
+func renderTrend(f *excelize.File, sheetName, title string, records [][]string) error {
+	headers := records[0]
+	if err := createFirstRow(f, sheetName, headers); err != nil {
+		return err
+	}
+
+	if len(records) == 1 {
+		log.Info().Msgf("Skipping %s. No data to render", sheetName)
+		return nil
+	}
+
+	records = records[1:]
+	currentRow := 4
+	for _, record := range records {
+		currentRow += 1
+		// values are written as numbers so they can be charted
+		row := make([]interface{}, len(record))
+		for i, v := range record {
+			row[i] = v
+			if n, err := strconv.ParseFloat(v, 64); err == nil && i > 0 {
+				row[i] = n
+			}
+		}
+
+		cell, err := excelize.CoordinatesToCellName(1, currentRow)
+		if err != nil {
+			return fmt.Errorf("failed to get cell: %w", err)
+		}
+		err = f.SetSheetRow(sheetName, cell, &row)
+		if err != nil {
+			return fmt.Errorf("failed to set row: %w", err)
+		}
+	}
+
+	if err := configureSheet(f, sheetName, headers, currentRow); err != nil {
+		return err
+	}
+
+	series := []excelize.ChartSeries{}
+	for col := 2; col <= len(headers); col++ {
+		name, err := excelize.ColumnNumberToName(col)
+		if err != nil {
+			return fmt.Errorf("failed to get column: %w", err)
+		}
+		series = append(series, excelize.ChartSeries{
+			Name:       fmt.Sprintf("%s!$%s$4", sheetName, name),
+			Categories: fmt.Sprintf("%s!$A$5:$A$%d", sheetName, currentRow),
+			Values:     fmt.Sprintf("%s!$%s$5:$%s$%d", sheetName, name, name, currentRow),
+		})
+	}
+	if len(series) == 0 {
+		return nil
+	}
+
+	cell, err := excelize.CoordinatesToCellName(len(headers)+2, 4)
+	if err != nil {
+		return fmt.Errorf("failed to get cell: %w", err)
+	}
+	err = f.AddChart(sheetName, cell, &excelize.Chart{
+		Type:   excelize.Line,
+		Series: series,
+		Title:  []excelize.RichTextRun{{Text: title}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add chart: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+// Package html renders reports as self-contained HTML documents.
+package html
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	chartWidth   = 720
+	chartHeight  = 260
+	chartPadding = 40
+)
+
+// palette of the lines of the charts, one per subscription
+var palette = []string{"#0078d4", "#d83b01", "#107c10", "#5c2d91", "#008272", "#e81123", "#ffb900", "#004b50"}
+
+type (
+	trendChart struct {
+		Title  string
+		Table  [][]string
+		Lines  []trendLine
+		Labels []trendLabel
+		Max    float64
+	}
+
+	trendLine struct {
+		Name   string
+		Color  string
+		Points string
+	}
+
+	trendLabel struct {
+		X    float64
+		Text string
+	}
+)
+
+var trendsTemplate = template.Must(template.New("trends").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Azure Quick Review Trends</title>
+<style>
+body { font-family: Segoe UI, sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th { background: #caedfb; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+svg { display: block; margin-bottom: 1em; }
+</style>
+</head>
+<body>
+<h1>Azure Quick Review Trends</h1>
+{{range .Charts}}
+<h2>{{.Title}}</h2>
+<svg width="{{$.Width}}" height="{{$.Height}}" xmlns="http://www.w3.org/2000/svg">
+<line x1="{{$.Padding}}" y1="{{$.Bottom}}" x2="{{$.Right}}" y2="{{$.Bottom}}" stroke="#999"/>
+<line x1="{{$.Padding}}" y1="{{$.Padding}}" x2="{{$.Padding}}" y2="{{$.Bottom}}" stroke="#999"/>
+<text x="4" y="{{$.Padding}}" font-size="11">{{.Max}}</text>
+<text x="4" y="{{$.Bottom}}" font-size="11">0</text>
+{{range .Labels}}<text x="{{.X}}" y="{{$.Height}}" font-size="11" text-anchor="middle">{{.Text}}</text>
+{{end}}{{range .Lines}}<polyline fill="none" stroke="{{.Color}}" stroke-width="2" points="{{.Points}}"><title>{{.Name}}</title></polyline>
+{{end}}</svg>
+<p>{{range .Lines}}<span style="color:{{.Color}}">&#9632;</span> {{.Name}} {{end}}</p>
+<table>
+{{range $i, $row := .Table}}<tr>{{range $row}}{{if eq $i 0}}<th>{{.}}</th>{{else}}<td>{{.}}</td>{{end}}{{end}}</tr>
+{{end}}</table>
+{{end}}
+<h2>Open Findings</h2>
+<table>
+{{range $i, $row := .Findings}}<tr>{{range $row}}{{if eq $i 0}}<th>{{.}}</th>{{else}}<td>{{.}}</td>{{end}}{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// CreateTrendsReport writes the weekly trends of the scans to <OutputFileName>.html
+func CreateTrendsReport(data *renderers.TrendsData) error {
+	filename := fmt.Sprintf("%s.html", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating html: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil {
+			log.Warn().Err(cerr).Msg("error closing file:")
+		}
+	}()
+
+	err = trendsTemplate.Execute(f, map[string]interface{}{
+		"Width":   chartWidth,
+		"Height":  chartHeight,
+		"Padding": chartPadding,
+		"Bottom":  chartHeight - chartPadding,
+		"Right":   chartWidth - chartPadding,
+		"Charts": []trendChart{
+			newTrendChart("Open High Impact Findings", data.HighImpactTable(), 0),
+			newTrendChart("Defender Plans Enabled (%)", data.DefenderCoverageTable(), 100),
+		},
+		"Findings": data.FindingsTable(),
+	})
+	if err != nil {
+		return fmt.Errorf("error writing html: %w", err)
+	}
+	return nil
+}
+
+// newTrendChart plots a table with a row per week and a column per subscription.
+// The vertical axis goes up to max, or to the largest value if max is 0.
+func newTrendChart(title string, table [][]string, max float64) trendChart {
+	chart := trendChart{Title: title, Table: table, Lines: []trendLine{}, Labels: []trendLabel{}}
+	rows := table[1:]
+
+	if max == 0 {
+		for _, row := range rows {
+			for _, v := range row[1:] {
+				if n, err := strconv.ParseFloat(v, 64); err == nil && n > max {
+					max = n
+				}
+			}
+		}
+	}
+	if max == 0 {
+		max = 1
+	}
+	chart.Max = max
+
+	step := float64(chartWidth - 2*chartPadding)
+	if len(rows) > 1 {
+		step /= float64(len(rows) - 1)
+	}
+	x := func(i int) float64 {
+		return chartPadding + float64(i)*step
+	}
+
+	for i, row := range rows {
+		chart.Labels = append(chart.Labels, trendLabel{X: x(i), Text: row[0]})
+	}
+
+	for col := 1; col < len(table[0]); col++ {
+		points := []string{}
+		for i, row := range rows {
+			n, err := strconv.ParseFloat(row[col], 64)
+			if err != nil {
+				// subscription not scanned that week
+				continue
+			}
+			y := float64(chartHeight-chartPadding) - n/max*float64(chartHeight-2*chartPadding)
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x(i), y))
+		}
+		chart.Lines = append(chart.Lines, trendLine{
+			Name:   table[0][col],
+			Color:  palette[(col-1)%len(palette)],
+			Points: strings.Join(points, " "),
+		})
+	}
+	return chart
+}
@@ -0,0 +1,23 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package json
+
+import (
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/iancoleman/strcase"
+)
+
+// CreateCompareReport writes the differences between two scans to <OutputFileName>.<table>.json files
+func CreateCompareReport(data *renderers.CompareData) error {
+	if err := writeData(data.SummaryTable(), data.OutputFileName, "summary"); err != nil {
+		return err
+	}
+
+	for _, t := range data.Tables() {
+		if err := writeData(t.Data, data.OutputFileName, strcase.ToLowerCamel(t.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -11,31 +11,54 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func CreateJsonReport(data *renderers.ReportData) {
-	writeData(data.RecommendationsTable(), data.OutputFileName, "recommendations")
-	writeData(data.ImpactedTable(), data.OutputFileName, "impacted")
-	writeData(data.ResourceTypesTable(), data.OutputFileName, "resourceType")
-	writeData(data.ResourcesTable(), data.OutputFileName, "inventory")
-	writeData(data.DefenderTable(), data.OutputFileName, "defender")
-	writeData(data.DefenderRecommendationsTable(), data.OutputFileName, "defenderRecommendations")
-	writeData(data.AdvisorTable(), data.OutputFileName, "advisor")
-	writeData(data.CostTable(), data.OutputFileName, "costs")
-	writeData(data.ExcludedResourcesTable(), data.OutputFileName, "outofscope")
+func CreateJsonReport(data *renderers.ReportData) error {
+	tables := []struct {
+		data      [][]string
+		extension string
+	}{
+		{data.RecommendationsTable(), "recommendations"},
+		{data.ImpactedTable(), "impacted"},
+		{data.SuppressedTable(), "suppressed"},
+		{data.ResourceTypesTable(), "resourceType"},
+		{data.ResourcesTable(), "inventory"},
+		{data.DefenderTable(), "defender"},
+		{data.DefenderRecommendationsTable(), "defenderRecommendations"},
+		{data.AdvisorTable(), "advisor"},
+		{data.CostTable(), "costs"},
+		{data.ExcludedResourcesTable(), "outofscope"},
+		{data.ErrorsTable(), "errors"},
+		{data.ExceptionsTable(), "exceptions"},
+	}
+
+	// the baseline table is only written if the scan was compared to a baseline
+	if data.Baseline != nil {
+		tables = append(tables, struct {
+			data      [][]string
+			extension string
+		}{data.BaselineTable(), "baseline"})
+	}
+
+	for _, t := range tables {
+		if err := writeData(t.data, data.OutputFileName, t.extension); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func writeData(data [][]string, fileName, extension string) {
+func writeData(data [][]string, fileName, extension string) error {
 	filename := fmt.Sprintf("%s.%s.json", fileName, extension)
 	log.Info().Msgf("Generating Report: %s", filename)
 
 	f, err := os.Create(filename)
 	if err != nil {
-		log.Fatal().Err(err).Msg("error creating json:")
+		return fmt.Errorf("error creating json: %w", err)
 	}
-	
+
 	defer func() {
 		// Handle error during file close
 		if cerr := f.Close(); cerr != nil {
-			log.Fatal().Err(cerr).Msg("error closing file:")
+			log.Warn().Err(cerr).Msg("error closing file:")
 		}
 	}()
 
@@ -43,13 +66,14 @@ func writeData(data [][]string, fileName, extension string) {
 
 	js, err := json.MarshalIndent(jsonData, "", "\t")
 	if err != nil {
-		log.Fatal().Err(err).Msg("error marshaling data:")
+		return fmt.Errorf("error marshaling data: %w", err)
 	}
 
 	_, err = f.Write(js)
 	if err != nil {
-		log.Fatal().Err(err).Msg("error writing json:")
+		return fmt.Errorf("error writing json: %w", err)
 	}
+	return nil
 }
 
 func convertToJSON(data [][]string) []map[string]string {
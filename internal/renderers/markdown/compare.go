@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+// Package markdown renders reports as markdown documents.
+package markdown
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
+)
+
+// CreateCompareReport writes the differences between two scans to <OutputFileName>.md
+func CreateCompareReport(data *renderers.CompareData) error {
+	filename := fmt.Sprintf("%s.md", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	var sb strings.Builder
+	sb.WriteString("# Azure Quick Review Comparison\n\n")
+	writeTable(&sb, data.SummaryTable())
+
+	for _, t := range data.Tables() {
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", t.Name))
+		if len(t.Data) == 1 {
+			sb.WriteString("No differences.\n")
+			continue
+		}
+		writeTable(&sb, t.Data)
+	}
+
+	if err := os.WriteFile(filename, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("error writing markdown: %w", err)
+	}
+	return nil
+}
+
+// writeTable writes the headers and rows of a table as a markdown table
+func writeTable(sb *strings.Builder, records [][]string) {
+	for i, row := range records {
+		cells := make([]string, len(row))
+		for j, c := range row {
+			cells[j] = strings.ReplaceAll(strings.ReplaceAll(c, "|", "\\|"), "\n", " ")
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+
+		if i == 0 {
+			sb.WriteString(strings.Repeat("|---", len(row)) + "|\n")
+		}
+	}
+}
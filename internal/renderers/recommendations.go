@@ -12,9 +12,10 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func GetAllRecommendations(md bool) string {
+// GetAllRecommendations lists the built-in, APRL, rule pack and custom recommendations as markdown or JSON
+func GetAllRecommendations(md bool, customRules models.CustomRules, rulePacks []string) string {
 	_, serviceScanners := models.GetScanners()
-	aprlScanner := graph.NewAprlScanner(serviceScanners, nil, nil)
+	aprlScanner := graph.NewAprlScanner(serviceScanners, nil, nil, rulePacks)
 	aprl := aprlScanner.GetAprlRecommendations()
 
 	var output string
@@ -33,6 +34,9 @@ func GetAllRecommendations(md bool) string {
 			for _, r := range rm {
 				recommendations[r.RecommendationID] = r
 			}
+			for _, r := range customRules.ForResourceTypes(scanner.ResourceTypes()) {
+				recommendations[r.RecommendationID] = r
+			}
 
 			keys := make([]string, 0, len(recommendations))
 			for k := range recommendations {
@@ -69,6 +73,9 @@ func GetAllRecommendations(md bool) string {
 			for _, r := range rm {
 				recommendations[r.RecommendationID] = r
 			}
+			for _, r := range customRules.ForResourceTypes(scanner.ResourceTypes()) {
+				recommendations[r.RecommendationID] = r
+			}
 
 			keys := make([]string, 0, len(recommendations))
 			for k := range recommendations {
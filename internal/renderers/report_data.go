@@ -12,10 +12,12 @@ import (
 
 type (
 	ReportData struct {
-		OutputFileName          string
-		Mask                    bool
-		Azqr                    []models.AzqrServiceResult
-		Aprl                    []models.AprlResult
+		OutputFileName string
+		Mask           bool
+		Azqr           []models.AzqrServiceResult
+		Aprl           []models.AprlResult
+		// AprlSuppressed are the APRL results suppressed by the azqr-suppress tag of the resources
+		AprlSuppressed          []models.AprlResult
 		Defender                []models.DefenderResult
 		DefenderRecommendations []models.DefenderRecommendation
 		Advisor                 []models.AdvisorResult
@@ -24,6 +26,13 @@ type (
 		Resources               []*models.Resource
 		ExludedResources        []*models.Resource
 		ResourceTypeCount       []models.ResourceTypeCount
+		Errors                  []models.ScanError
+		// Exceptions are the exceptions of the filters file with their status at scan time
+		Exceptions []models.ExceptionResult
+		// Baseline splits the findings by their presence in the baseline. Nil if the scan was not compared to a baseline.
+		Baseline *models.BaselineComparison
+		// Incomplete is set when the scan was interrupted before all results were collected
+		Incomplete bool
 	}
 
 	ResourceTypeCountResults struct {
@@ -40,7 +49,7 @@ func (rd *ReportData) ExcludedResourcesTable() [][]string {
 }
 
 func (rd *ReportData) ImpactedTable() [][]string {
-	headers := []string{"Validated Using", "Source", "Category", "Impact", "Resource Type", "Recommendation", "Recommendation Id", "Subscription Id", "Subscription Name", "Resource Group", "Resource Name", "Resource Id", "Param1", "Param2", "Param3", "Param4", "Param5", "Learn"}
+	headers := []string{"Validated Using", "Source", "Category", "Impact", "Resource Type", "Recommendation", "Recommendation Id", "Subscription Id", "Subscription Name", "Resource Group", "Resource Name", "Resource Id", "Param1", "Param2", "Param3", "Param4", "Param5", "Learn", "Status", "Evidence"}
 
 	rows := [][]string{}
 	for _, r := range rd.Aprl {
@@ -63,16 +72,23 @@ func (rd *ReportData) ImpactedTable() [][]string {
 			r.Param4,
 			r.Param5,
 			r.Learn,
+			string(models.StatusNotCompliant),
+			"",
 		}
 		rows = append(rows, row)
 	}
 
 	for _, d := range rd.Azqr {
 		for _, r := range d.Recommendations {
-			if r.NotCompliant {
+			// rules that failed are listed with the error as Param1
+			if r.NotCompliant || r.Status == models.StatusError {
+				source := r.Source
+				if source == "" {
+					source = "AZQR"
+				}
 				row := []string{
 					"Azure Resource Manager",
-					"AZQR",
+					source,
 					string(r.Category),
 					string(r.Impact),
 					d.Type,
@@ -89,6 +105,8 @@ func (rd *ReportData) ImpactedTable() [][]string {
 					"",
 					"",
 					r.LearnMoreUrl,
+					string(r.Status),
+					rd.evidence(r.Evidence, d.SubscriptionID),
 				}
 				rows = append(rows, row)
 			}
@@ -99,6 +117,64 @@ func (rd *ReportData) ImpactedTable() [][]string {
 	return rows
 }
 
+// SuppressedTable lists the not compliant resources whose recommendations are suppressed by their azqr-suppress tag
+func (rd *ReportData) SuppressedTable() [][]string {
+	headers := []string{"Validated Using", "Source", "Category", "Impact", "Resource Type", "Recommendation", "Recommendation Id", "Subscription Id", "Subscription Name", "Resource Group", "Resource Name", "Resource Id", "Reason", "Learn"}
+
+	rows := [][]string{}
+	for _, r := range rd.AprlSuppressed {
+		row := []string{
+			"Azure Resource Graph",
+			r.Source,
+			string(r.Category),
+			string(r.Impact),
+			r.ResourceType,
+			r.Recommendation,
+			r.RecommendationID,
+			MaskSubscriptionID(r.SubscriptionID, rd.Mask),
+			r.SubscriptionName,
+			r.ResourceGroup,
+			r.Name,
+			MaskSubscriptionIDInResourceID(r.ResourceID, rd.Mask),
+			r.SuppressionReason,
+			r.Learn,
+		}
+		rows = append(rows, row)
+	}
+
+	for _, d := range rd.Azqr {
+		for _, r := range d.Recommendations {
+			if r.Status != models.StatusSuppressed {
+				continue
+			}
+			source := r.Source
+			if source == "" {
+				source = "AZQR"
+			}
+			row := []string{
+				"Azure Resource Manager",
+				source,
+				string(r.Category),
+				string(r.Impact),
+				d.Type,
+				r.Recommendation,
+				r.RecommendationID,
+				MaskSubscriptionID(d.SubscriptionID, rd.Mask),
+				d.SubscriptionName,
+				d.ResourceGroup,
+				d.ServiceName,
+				MaskSubscriptionIDInResourceID(d.ResourceID(), rd.Mask),
+				r.SuppressionReason,
+				r.LearnMoreUrl,
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
 func (rd *ReportData) CostTable() [][]string {
 	headers := []string{"From", "To", "Subscription Id", "Subscription Name", "Service Name", "Value", "Currency"}
 
@@ -171,21 +247,35 @@ func (rd *ReportData) RecommendationsTable() [][]string {
 		counter[r.RecommendationID]++
 	}
 
+	errors := map[string]int{}
+	notApplicable := map[string]int{}
+	suppressed := map[string]int{}
+	for _, r := range rd.AprlSuppressed {
+		suppressed[r.RecommendationID]++
+	}
 	for _, d := range rd.Azqr {
 		for _, r := range d.Recommendations {
-			if r.NotCompliant {
+			switch {
+			case r.NotCompliant:
 				counter[r.RecommendationID]++
+			case r.Status == models.StatusError:
+				errors[r.RecommendationID]++
+			case r.Status == models.StatusNotApplicable:
+				notApplicable[r.RecommendationID]++
+			case r.Status == models.StatusSuppressed:
+				suppressed[r.RecommendationID]++
 			}
 		}
 	}
 
 	headers := []string{"Implemented", "Number of Impacted Resources", "Azure Service / Well-Architected", "Recommendation Source",
 		"Azure Service Category / Well-Architected Area", "Azure Service / Well-Architected Topic", "Resiliency Category", "Recommendation",
-		"Impact", "Best Practices Guidance", "Read More", "Recommendation Id"}
+		"Impact", "Best Practices Guidance", "Read More", "Recommendation Id", "Number of Errors", "Number of Not Applicable Resources", "Number of Suppressed Resources"}
 	rows := [][]string{}
 	for _, rt := range rd.Recommendations {
 		for _, r := range rt {
-			implemented := counter[r.RecommendationID] == 0
+			// a recommendation is not known to be implemented if it failed for any resource
+			implemented := counter[r.RecommendationID] == 0 && errors[r.RecommendationID] == 0
 			categoryPart := ""
 			servicePart := ""
 			typeParts := strings.Split(r.ResourceType, "/")
@@ -207,6 +297,9 @@ func (rd *ReportData) RecommendationsTable() [][]string {
 				r.LongDescription,
 				r.LearnMoreLink[0].Url,
 				r.RecommendationID,
+				fmt.Sprint(errors[r.RecommendationID]),
+				fmt.Sprint(notApplicable[r.RecommendationID]),
+				fmt.Sprint(suppressed[r.RecommendationID]),
 			}
 			rows = append(rows, row)
 		}
@@ -261,6 +354,100 @@ func (rd *ReportData) DefenderRecommendationsTable() [][]string {
 	return rows
 }
 
+func (rd *ReportData) ErrorsTable() [][]string {
+	headers := []string{"Subscription Id", "Subscription Name", "Scanner", "Resource Type", "Error"}
+	rows := [][]string{}
+	for _, e := range rd.Errors {
+		row := []string{
+			MaskSubscriptionID(e.SubscriptionID, rd.Mask),
+			e.SubscriptionName,
+			e.Scanner,
+			e.ResourceType,
+			e.Error,
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
+// ExceptionsTable lists the active and expired exceptions of the filters file
+func (rd *ReportData) ExceptionsTable() [][]string {
+	headers := []string{"Status", "Resource Id", "Recommendation Id", "Owner", "Reason", "Expires", "Number of Findings"}
+	rows := [][]string{}
+	for _, e := range rd.Exceptions {
+		row := []string{
+			string(e.Status),
+			MaskSubscriptionIDInResourceID(e.Exception.Resource, rd.Mask),
+			e.Exception.Recommendation,
+			e.Exception.Owner,
+			e.Exception.Reason,
+			e.Exception.Expires,
+			fmt.Sprint(e.Findings),
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
+// Findings returns the AZQR, APRL, Advisor and Defender findings, with the subscription ID of the resources masked if required
+func (rd *ReportData) Findings() []models.Finding {
+	findings := []models.Finding{}
+	for _, d := range rd.Azqr {
+		for _, r := range d.Recommendations {
+			if r.NotCompliant {
+				findings = append(findings, models.NewFinding(models.FindingSourceAzqr, r.RecommendationID, r.Recommendation, d.ResourceID(), MaskSubscriptionIDInResourceID(d.ResourceID(), rd.Mask)))
+			}
+		}
+	}
+
+	for _, r := range rd.Aprl {
+		findings = append(findings, models.NewFinding(models.FindingSourceAprl, r.RecommendationID, r.Recommendation, r.ResourceID, MaskSubscriptionIDInResourceID(r.ResourceID, rd.Mask)))
+	}
+
+	for _, r := range rd.Advisor {
+		findings = append(findings, models.NewFinding(models.FindingSourceAdvisor, r.RecommendationID, r.Description, r.ResourceID, MaskSubscriptionIDInResourceID(r.ResourceID, rd.Mask)))
+	}
+
+	for _, r := range rd.DefenderRecommendations {
+		findings = append(findings, models.NewFinding(models.FindingSourceDefender, r.RecommendationName, r.RecommendationName, r.ResourceId, MaskSubscriptionIDInResourceID(r.ResourceId, rd.Mask)))
+	}
+	return findings
+}
+
+// BaselineTable lists the new, existing and resolved findings compared to the baseline
+func (rd *ReportData) BaselineTable() [][]string {
+	headers := []string{"State", "Source", "Recommendation Id", "Recommendation", "Resource Id"}
+	rows := [][]string{}
+	if rd.Baseline != nil {
+		for _, state := range []struct {
+			name     string
+			findings []models.Finding
+		}{
+			{"New", rd.Baseline.New},
+			{"Existing", rd.Baseline.Existing},
+			{"Resolved", rd.Baseline.Resolved},
+		} {
+			for _, f := range state.findings {
+				row := []string{
+					state.name,
+					string(f.Source),
+					f.RecommendationID,
+					f.Recommendation,
+					f.ResourceID,
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
 func (rd *ReportData) ResourceIDs() []*string {
 	ids := []*string{}
 	for _, r := range rd.Resources {
@@ -277,6 +464,7 @@ func NewReportData(outputFile string, mask bool) ReportData {
 		Recommendations:         map[string]map[string]models.AprlRecommendation{},
 		Azqr:                    []models.AzqrServiceResult{},
 		Aprl:                    []models.AprlResult{},
+		AprlSuppressed:          []models.AprlResult{},
 		Defender:                []models.DefenderResult{},
 		DefenderRecommendations: []models.DefenderRecommendation{},
 		Advisor:                 []models.AdvisorResult{},
@@ -284,7 +472,22 @@ func NewReportData(outputFile string, mask bool) ReportData {
 			Items: []*models.CostResultItem{},
 		},
 		ResourceTypeCount: []models.ResourceTypeCount{},
+		Errors:            []models.ScanError{},
+		Exceptions:        []models.ExceptionResult{},
+	}
+}
+
+// evidence joins the evidence of a result, masking the subscription ID if required
+func (rd *ReportData) evidence(evidence []models.Evidence, subscriptionID string) string {
+	values := make([]string, len(evidence))
+	for i, e := range evidence {
+		values[i] = e.String()
+	}
+	joined := strings.Join(values, "; ")
+	if rd.Mask && subscriptionID != "" {
+		joined = strings.ReplaceAll(joined, subscriptionID, MaskSubscriptionID(subscriptionID, rd.Mask))
 	}
+	return joined
 }
 
 func MaskSubscriptionID(subscriptionID string, mask bool) string {
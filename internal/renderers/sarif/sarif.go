@@ -0,0 +1,249 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+// Package sarif renders the findings of a scan as a SARIF 2.1.0 log, to be
+// ingested by GitHub code scanning, Defender for DevOps and other security tools.
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	schema         = "https://json.schemastore.org/sarif-2.1.0.json"
+	version        = "2.1.0"
+	informationURI = "https://azure.github.io/azqr"
+)
+
+type (
+	sarifLog struct {
+		Schema  string `json:"$schema"`
+		Version string `json:"version"`
+		Runs    []run  `json:"runs"`
+	}
+
+	run struct {
+		Tool    tool     `json:"tool"`
+		Results []result `json:"results"`
+	}
+
+	tool struct {
+		Driver driver `json:"driver"`
+	}
+
+	driver struct {
+		Name           string `json:"name"`
+		InformationURI string `json:"informationUri"`
+		Rules          []rule `json:"rules"`
+	}
+
+	rule struct {
+		ID                   string         `json:"id"`
+		ShortDescription     message        `json:"shortDescription"`
+		FullDescription      *message       `json:"fullDescription,omitempty"`
+		HelpURI              string         `json:"helpUri,omitempty"`
+		DefaultConfiguration configuration  `json:"defaultConfiguration"`
+		Properties           ruleProperties `json:"properties"`
+	}
+
+	configuration struct {
+		Level string `json:"level"`
+	}
+
+	ruleProperties struct {
+		Category     string   `json:"category,omitempty"`
+		Impact       string   `json:"impact,omitempty"`
+		ResourceType string   `json:"resourceType,omitempty"`
+		Source       string   `json:"source,omitempty"`
+		Tags         []string `json:"tags,omitempty"`
+	}
+
+	message struct {
+		Text string `json:"text"`
+	}
+
+	result struct {
+		RuleID              string            `json:"ruleId"`
+		RuleIndex           int               `json:"ruleIndex"`
+		Level               string            `json:"level"`
+		Message             message           `json:"message"`
+		Locations           []location        `json:"locations"`
+		PartialFingerprints map[string]string `json:"partialFingerprints"`
+	}
+
+	location struct {
+		PhysicalLocation physicalLocation  `json:"physicalLocation"`
+		LogicalLocations []logicalLocation `json:"logicalLocations"`
+	}
+
+	physicalLocation struct {
+		ArtifactLocation artifactLocation `json:"artifactLocation"`
+	}
+
+	artifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	logicalLocation struct {
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+		Kind               string `json:"kind"`
+	}
+)
+
+// CreateSarifReport writes the recommendations as rules and the impacted resources as results to <OutputFileName>.sarif
+func CreateSarifReport(data *renderers.ReportData) error {
+	filename := fmt.Sprintf("%s.sarif", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	js, err := json.MarshalIndent(newLog(data), "", "\t")
+	if err != nil {
+		return fmt.Errorf("error marshaling sarif: %w", err)
+	}
+
+	if err := os.WriteFile(filename, js, 0o644); err != nil {
+		return fmt.Errorf("error writing sarif: %w", err)
+	}
+	return nil
+}
+
+func newLog(data *renderers.ReportData) sarifLog {
+	rules := []rule{}
+	index := map[string]int{}
+	addRule := func(r rule) {
+		if _, ok := index[r.ID]; ok {
+			return
+		}
+		index[r.ID] = len(rules)
+		rules = append(rules, r)
+	}
+
+	recommendations := []models.AprlRecommendation{}
+	for _, rt := range data.Recommendations {
+		for _, r := range rt {
+			recommendations = append(recommendations, r)
+		}
+	}
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].RecommendationID < recommendations[j].RecommendationID
+	})
+	for _, r := range recommendations {
+		addRule(newRule(r))
+	}
+
+	// the resource ids are read unmasked for the fingerprints, and masked for the locations
+	unmasked := *data
+	unmasked.Mask = false
+
+	results := []result{}
+	table := unmasked.ImpactedTable()
+	col := map[string]int{}
+	for i, h := range table[0] {
+		col[h] = i
+	}
+	for _, row := range table[1:] {
+		// rules that failed to evaluate are reported as scan errors, not findings
+		if row[col["Status"]] == string(models.StatusError) {
+			continue
+		}
+
+		recommendationID := row[col["Recommendation Id"]]
+		rawResourceID := row[col["Resource Id"]]
+		resourceID := renderers.MaskSubscriptionIDInResourceID(rawResourceID, data.Mask)
+		impact := models.RecommendationImpact(row[col["Impact"]])
+
+		// recommendations of custom rules may not be in the catalog
+		addRule(rule{
+			ID:                   recommendationID,
+			ShortDescription:     message{Text: row[col["Recommendation"]]},
+			HelpURI:              row[col["Learn"]],
+			DefaultConfiguration: configuration{Level: level(impact)},
+			Properties: ruleProperties{
+				Category:     row[col["Category"]],
+				Impact:       string(impact),
+				ResourceType: row[col["Resource Type"]],
+				Source:       row[col["Source"]],
+				Tags:         []string{row[col["Category"]]},
+			},
+		})
+
+		text := row[col["Recommendation"]]
+		if detail := row[col["Param1"]]; detail != "" {
+			text = fmt.Sprintf("%s (%s)", text, detail)
+		}
+
+		results = append(results, result{
+			RuleID:    recommendationID,
+			RuleIndex: index[recommendationID],
+			Level:     level(impact),
+			Message:   message{Text: text},
+			Locations: []location{
+				{
+					PhysicalLocation: physicalLocation{ArtifactLocation: artifactLocation{URI: resourceID}},
+					LogicalLocations: []logicalLocation{{FullyQualifiedName: resourceID, Kind: "resource"}},
+				},
+			},
+			// the baseline fingerprint, of the unmasked resource id, lets code scanning track a finding across scans.
+			// Masked resource ids of different subscriptions can be the same, so they would collide.
+			PartialFingerprints: map[string]string{"azqrFingerprint/v1": models.Fingerprint(recommendationID, rawResourceID)},
+		})
+	}
+
+	return sarifLog{
+		Schema:  schema,
+		Version: version,
+		Runs: []run{
+			{
+				Tool: tool{
+					Driver: driver{
+						Name:           "azqr",
+						InformationURI: informationURI,
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+func newRule(r models.AprlRecommendation) rule {
+	impact := models.RecommendationImpact(r.Impact)
+	rl := rule{
+		ID:                   r.RecommendationID,
+		ShortDescription:     message{Text: r.Recommendation},
+		DefaultConfiguration: configuration{Level: level(impact)},
+		Properties: ruleProperties{
+			Category:     r.Category,
+			Impact:       r.Impact,
+			ResourceType: r.ResourceType,
+			Source:       r.Source,
+			Tags:         []string{r.Category},
+		},
+	}
+	if r.LongDescription != "" {
+		rl.FullDescription = &message{Text: r.LongDescription}
+	}
+	if len(r.LearnMoreLink) > 0 {
+		rl.HelpURI = r.LearnMoreLink[0].Url
+	}
+	return rl
+}
+
+// level maps the impact of a recommendation to a SARIF level
+func level(impact models.RecommendationImpact) string {
+	switch impact {
+	case models.ImpactHigh:
+		return "error"
+	case models.ImpactLow:
+		return "note"
+	default:
+		return "warning"
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package sarif
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+)
+
+func TestCreateSarifReport(t *testing.T) {
+	const aksID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks"
+	data := renderers.NewReportData(filepath.Join(t.TempDir(), "azqr"), false)
+
+	rec := models.AprlRecommendation{
+		RecommendationID: "aprl-1",
+		Recommendation:   "Enable availability zones",
+		Category:         string(models.CategoryHighAvailability),
+		Impact:           string(models.ImpactHigh),
+		ResourceType:     "Microsoft.ContainerService/managedClusters",
+		LongDescription:  "Spread the nodes across zones",
+		Source:           "APRL",
+	}
+	rec.LearnMoreLink = append(rec.LearnMoreLink, struct {
+		Name string `yaml:"name"`
+		Url  string `yaml:"url"`
+	}{Name: "zones", Url: "https://learn.microsoft.com/azure/aks/availability-zones"})
+	data.Recommendations = map[string]map[string]models.AprlRecommendation{
+		"microsoft.containerservice/managedclusters": {"aprl-1": rec},
+	}
+
+	data.Aprl = []models.AprlResult{
+		{RecommendationID: "aprl-1", Recommendation: rec.Recommendation, Category: models.CategoryHighAvailability, Impact: models.ImpactHigh, ResourceID: aksID},
+	}
+	data.Azqr = []models.AzqrServiceResult{
+		{
+			SubscriptionID: "00000000-0000-0000-0000-000000000000",
+			ResourceGroup:  "rg",
+			Type:           "Microsoft.ContainerService/managedClusters",
+			ServiceName:    "aks",
+			Recommendations: map[string]models.AzqrResult{
+				"aks-001": {RecommendationID: "aks-001", Recommendation: "Enable the Uptime SLA", Category: models.CategorySecurity, Impact: models.ImpactLow, NotCompliant: true, Status: models.StatusNotCompliant},
+				"aks-002": {RecommendationID: "aks-002", Category: models.CategorySecurity, Impact: models.ImpactMedium, Status: models.StatusError},
+			},
+		},
+	}
+
+	if err := CreateSarifReport(&data); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(data.OutputFileName + ".sarif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(content, &log); err != nil {
+		t.Fatal(err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("got version %s with %d runs, want 2.1.0 with 1 run", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	// custom recommendations not in the catalog are added as rules
+	if len(run.Tool.Driver.Rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(run.Tool.Driver.Rules))
+	}
+	r := run.Tool.Driver.Rules[0]
+	if r.ID != "aprl-1" || r.DefaultConfiguration.Level != "error" || r.HelpURI != rec.LearnMoreLink[0].Url {
+		t.Errorf("rule = %+v, want aprl-1 with level error and help uri", r)
+	}
+
+	// failed evaluations are not results
+	if len(run.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(run.Results))
+	}
+	levels := map[string]string{}
+	for _, res := range run.Results {
+		levels[res.RuleID] = res.Level
+		if run.Tool.Driver.Rules[res.RuleIndex].ID != res.RuleID {
+			t.Errorf("result %s has rule index %d of rule %s", res.RuleID, res.RuleIndex, run.Tool.Driver.Rules[res.RuleIndex].ID)
+		}
+		if res.Locations[0].LogicalLocations[0].FullyQualifiedName != aksID && res.RuleID == "aprl-1" {
+			t.Errorf("result located at %s, want %s", res.Locations[0].LogicalLocations[0].FullyQualifiedName, aksID)
+		}
+	}
+	if levels["aprl-1"] != "error" || levels["aks-001"] != "note" {
+		t.Errorf("levels = %v, want aprl-1 error and aks-001 note", levels)
+	}
+}
+
+func TestCreateSarifReport_Masked(t *testing.T) {
+	// masked, the resource ids of both subscriptions are the same
+	ids := []string{
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks",
+		"/subscriptions/11111111-1111-1111-1111-111110000000/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/aks",
+	}
+	data := renderers.NewReportData(filepath.Join(t.TempDir(), "azqr"), true)
+	data.Recommendations = map[string]map[string]models.AprlRecommendation{}
+	for _, id := range ids {
+		data.Aprl = append(data.Aprl, models.AprlResult{RecommendationID: "aprl-1", Impact: models.ImpactHigh, ResourceID: id})
+	}
+
+	if err := CreateSarifReport(&data); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(data.OutputFileName + ".sarif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(content, &log); err != nil {
+		t.Fatal(err)
+	}
+
+	results := log.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].PartialFingerprints["azqrFingerprint/v1"] == results[1].PartialFingerprints["azqrFingerprint/v1"] {
+		t.Error("results of different resources have the same fingerprint")
+	}
+	for i, res := range results {
+		if want := models.Fingerprint("aprl-1", ids[i]); res.PartialFingerprints["azqrFingerprint/v1"] != want {
+			t.Errorf("result %d fingerprint = %s, want the baseline fingerprint %s", i, res.PartialFingerprints["azqrFingerprint/v1"], want)
+		}
+		if want := renderers.MaskSubscriptionIDInResourceID(ids[i], true); res.Locations[0].LogicalLocations[0].FullyQualifiedName != want {
+			t.Errorf("result %d located at %s, want %s", i, res.Locations[0].LogicalLocations[0].FullyQualifiedName, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"fmt"
+)
+
+type (
+	// TrendsData - Metrics of the scans in the trend store, per week and subscription
+	TrendsData struct {
+		OutputFileName string
+		// Weeks are the ISO weeks with scans in chronological order, e.g. 2025-W02
+		Weeks []string
+		// Subscriptions are the labels of the subscriptions scanned in any week: their name, followed by their ID when several share it
+		Subscriptions []string
+		// HighImpact is the number of open High impact findings per week and subscription
+		HighImpact map[string]map[string]int
+		// DefenderCoverage is the percentage of Defender plans enabled per week and subscription
+		DefenderCoverage map[string]map[string]float64
+		// Findings are the number of open findings per week, subscription, source, category and impact
+		Findings []TrendFindingCount
+	}
+
+	// TrendFindingCount - Number of open findings of a week
+	TrendFindingCount struct {
+		Week, Subscription, Source, Category, Impact string
+		Count                                        int
+	}
+)
+
+// HighImpactTable lists the open High impact findings per week, with a column per subscription
+func (td *TrendsData) HighImpactTable() [][]string {
+	headers := append([]string{"Week"}, td.Subscriptions...)
+	rows := [][]string{}
+	for _, w := range td.Weeks {
+		row := []string{w}
+		for _, s := range td.Subscriptions {
+			count, ok := td.HighImpact[w][s]
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, fmt.Sprint(count))
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
+// DefenderCoverageTable lists the percentage of Defender plans enabled per week, with a column per subscription
+func (td *TrendsData) DefenderCoverageTable() [][]string {
+	headers := append([]string{"Week"}, td.Subscriptions...)
+	rows := [][]string{}
+	for _, w := range td.Weeks {
+		row := []string{w}
+		for _, s := range td.Subscriptions {
+			coverage, ok := td.DefenderCoverage[w][s]
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, fmt.Sprintf("%.0f", coverage))
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
+
+// FindingsTable lists the open findings per week, subscription, source, category and impact
+func (td *TrendsData) FindingsTable() [][]string {
+	headers := []string{"Week", "Subscription", "Source", "Category", "Impact", "Number of Findings"}
+	rows := [][]string{}
+	for _, f := range td.Findings {
+		row := []string{
+			f.Week,
+			f.Subscription,
+			f.Source,
+			f.Category,
+			f.Impact,
+			fmt.Sprint(f.Count),
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
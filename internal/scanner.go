@@ -5,25 +5,35 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/Azure/azqr/internal/az"
+	"github.com/Azure/azqr/internal/checkpoint"
+	"github.com/Azure/azqr/internal/gate"
 	"github.com/Azure/azqr/internal/graph"
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azqr/internal/renderers"
 	"github.com/Azure/azqr/internal/renderers/csv"
 	"github.com/Azure/azqr/internal/renderers/excel"
 	"github.com/Azure/azqr/internal/renderers/json"
+	"github.com/Azure/azqr/internal/renderers/sarif"
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azqr/internal/throttling"
+	"github.com/Azure/azqr/internal/trends"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
 
 	_ "github.com/Azure/azqr/internal/scanners/aa"
 	_ "github.com/Azure/azqr/internal/scanners/adf"
@@ -107,15 +117,48 @@ type (
 		Mask                    bool
 		Csv                     bool
 		Json                    bool
+		Sarif                   bool
 		Debug                   bool
 		ScannerKeys             []string
 		ForceAzureCliCredential bool
 		Filters                 *models.Filters
 		UseAzqrRecommendations  bool
 		UseAprlRecommendations  bool
+		// Credential is used to authenticate against Azure. If nil, a default credential is created.
+		Credential azcore.TokenCredential
+		// CheckpointDir is the directory where results are persisted as they finish. Disabled if empty.
+		CheckpointDir string
+		// Resume skips the work already persisted in CheckpointDir
+		Resume bool
+		// ParallelSubscriptions is the number of subscriptions scanned at the same time. Values below 1 scan one at a time.
+		ParallelSubscriptions int
+		// Transport sends the HTTP requests of every Azure client. If nil, the azcore default transport is used.
+		// Used to record or replay scans.
+		Transport policy.Transporter
+		// Cloud is the name of the Azure cloud to scan. If empty, AZURE_ENVIRONMENT is used, defaulting to Azure Public Cloud.
+		Cloud string
+		// RulesDir is a directory of YAML rules evaluated alongside the built-in recommendations. Disabled if empty.
+		RulesDir string
+		// RulePacks are directories, e.g. git checkouts, of recommendation YAML and KQL files in the APRL format
+		RulePacks []string
+		// Baseline is a baseline file the findings are compared to, splitting them into new, existing and resolved. Disabled if empty.
+		Baseline string
+		// WriteBaseline is the file where the findings of a complete scan are written as a baseline. Disabled if empty.
+		WriteBaseline string
+		// TrendsFile is the JSON Lines file the metrics of a complete scan are appended to, to render trend reports. Disabled if empty.
+		TrendsFile string
+		// FailOn are the quality gate conditions. Scan exits with gate.ExitThresholdExceeded if any is met.
+		FailOn []*gate.Condition
 	}
 
 	Scanner struct{}
+
+	// serviceScanResult holds the outcome of a single service scanner run
+	serviceScanResult struct {
+		scanner models.IAzureScanner
+		results []models.AzqrServiceResult
+		err     error
+	}
 )
 
 const (
@@ -135,16 +178,20 @@ func NewScanParams() *ScanParams {
 		Mask:                    true,
 		Csv:                     false,
 		Json:                    false,
+		Sarif:                   false,
 		Debug:                   false,
 		ScannerKeys:             []string{},
 		ForceAzureCliCredential: false,
 		Filters:                 models.NewFilters(),
 		UseAzqrRecommendations:  true,
 		UseAprlRecommendations:  true,
+		ParallelSubscriptions:   1,
 	}
 }
 
-func (sc Scanner) Scan(params *ScanParams) {
+// Scan scans Azure resources and renders the reports selected in params. Any error is fatal.
+// If ctx is cancelled, the results collected so far are rendered as an incomplete report.
+func (sc Scanner) Scan(ctx context.Context, params *ScanParams) {
 	startTime := time.Now()
 	// Default level for this example is info, unless debug flag is present
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
@@ -153,23 +200,95 @@ func (sc Scanner) Scan(params *ScanParams) {
 		log.Debug().Msg("Debug logging enabled")
 	}
 
+	reportData, err := sc.ScanReport(ctx, params)
+	if err != nil && (reportData == nil || !reportData.Incomplete) {
+		log.Error().Err(err).Msg("Failed to scan")
+		os.Exit(gate.ExitScanError)
+	}
+
+	if err := sc.Render(reportData, params); err != nil {
+		log.Error().Err(err).Msg("Failed to render reports")
+		os.Exit(gate.ExitScanError)
+	}
+
+	if reportData.Incomplete {
+		if params.CheckpointDir != "" {
+			log.Info().Msgf("Run the scan again with --resume %s to continue", params.CheckpointDir)
+		}
+		log.Error().Msgf("Scan interrupted. Incomplete report saved as %s", reportData.OutputFileName)
+		os.Exit(gate.ExitScanError)
+	}
+
+	elapsedTime := time.Since(startTime)
+	// Format the elapsed time as HH:MM:SS and log the scan completion time
+	hours := int(elapsedTime.Hours())
+	minutes := int(elapsedTime.Minutes()) % 60
+	seconds := int(elapsedTime.Seconds()) % 60
+	log.Info().Msgf("Scan completed in %02d:%02d:%02d", hours, minutes, seconds)
+
+	results := sc.checkQualityGate(reportData, params.FailOn)
+	switch code := gate.ExitCode(reportData, results); code {
+	case gate.ExitThresholdExceeded:
+		log.Error().Msg("Findings over the quality gate threshold")
+		os.Exit(code)
+	case gate.ExitScanError:
+		log.Error().Msg("Scanners failed: some resources were not assessed")
+		os.Exit(code)
+	}
+}
+
+// checkQualityGate logs the number of findings matching every quality gate condition and returns the results
+func (sc Scanner) checkQualityGate(reportData *renderers.ReportData, conditions []*gate.Condition) []gate.Result {
+	results, err := gate.Evaluate(reportData, conditions)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to evaluate quality gate")
+		os.Exit(gate.ExitScanError)
+	}
+
+	for _, r := range results {
+		if r.Failed {
+			log.Error().Msgf("Quality gate %s failed: %d findings", r.Condition.Expression, r.Count)
+		} else {
+			log.Info().Msgf("Quality gate %s passed: %d findings", r.Condition.Expression, r.Count)
+		}
+	}
+	return results
+}
+
+// ScanReport scans Azure resources and returns the collected report data without rendering it.
+func (sc Scanner) ScanReport(ctx context.Context, params *ScanParams) (*renderers.ReportData, error) {
 	// generate output file name
 	outputFile := sc.generateOutputFileName(params.OutputName)
 
 	// load filters
 	filters := params.Filters
+	if filters == nil {
+		return nil, errors.New("filters must be set")
+	}
 
 	// validate input
 	if len(params.ManagementGroups) > 0 && (len(params.Subscriptions) > 0 || len(params.ResourceGroups) > 0) {
-		log.Fatal().Msg("Management Group name cannot be used with a Subscription Id or Resource Group name")
+		return nil, errors.New("management Group name cannot be used with a Subscription Id or Resource Group name")
 	}
 
 	if len(params.Subscriptions) < 1 && len(params.ResourceGroups) > 0 {
-		log.Fatal().Msg("Resource Group name can only be used with a Subscription Id")
+		return nil, errors.New("resource Group name can only be used with a Subscription Id")
 	}
 
 	if len(params.Subscriptions) > 1 && len(params.ResourceGroups) > 0 {
-		log.Fatal().Msg("Resource Group name can only be used with 1 Subscription Id")
+		return nil, errors.New("resource Group name can only be used with 1 Subscription Id")
+	}
+
+	for _, c := range params.FailOn {
+		if c.New && params.Baseline == "" {
+			return nil, fmt.Errorf("quality gate %s requires a baseline", c.Expression)
+		}
+	}
+
+	for _, dir := range params.RulePacks {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("rule pack %s is not a directory", dir)
+		}
 	}
 
 	if len(params.Subscriptions) > 0 {
@@ -186,16 +305,67 @@ func (sc Scanner) Scan(params *ScanParams) {
 
 	serviceScanners := filters.Azqr.Scanners
 
+	// select the Azure cloud
+	cloudConfig, err := az.ParseCloud(params.Cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	// load custom rules
+	var customRules models.CustomRules
+	if params.RulesDir != "" {
+		customRules, err = models.LoadCustomRules(params.RulesDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// load the baseline
+	var baseline *models.Baseline
+	if params.Baseline != "" {
+		baseline, err = models.LoadBaseline(params.Baseline)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// open the checkpoint store
+	store, err := sc.openCheckpoint(params)
+	if err != nil {
+		return nil, err
+	}
+
 	// create Azure credentials
-	cred := sc.newAzureCredential(params.ForceAzureCliCredential)
+	cred := params.Credential
+	if cred == nil {
+		cred, err = sc.newAzureCredential(params.ForceAzureCliCredential, cloudConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// create a cancelable context
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// initialize report data
+	reportData := renderers.NewReportData(outputFile, params.Mask)
+
+	// addError records a tenant wide scanner failure
+	addError := func(scanner, resourceType string, err error) {
+		reportData.Errors = appendScanError(ctx, reportData.Errors, "", "", scanner, resourceType, false, err)
+	}
+
 	// create ARM client options
+	// adapt the request rate of every ARM client to the remaining reads reported by ARM
+	armLimiter := throttling.NewAdaptiveLimiter()
+	defer sc.logThrottlingMetrics(armLimiter)
+
 	clientOptions := &arm.ClientOptions{
 		ClientOptions: policy.ClientOptions{
+			Cloud:            cloudConfig,
+			PerRetryPolicies: []policy.Policy{armLimiter.Policy()},
+			Transport:        params.Transport,
 			Retry: policy.RetryOptions{
 				// Only if the HTTP response does not contain a Retry-After header
 				RetryDelay:    1 * time.Second, // More agressive than default (4s)
@@ -209,37 +379,47 @@ func (sc Scanner) Scan(params *ScanParams) {
 	var subscriptions map[string]string
 	if len(params.ManagementGroups) > 0 {
 		managementGroupScanner := scanners.ManagementGroupsScanner{}
-		subscriptions = managementGroupScanner.ListSubscriptions(ctx, cred, params.ManagementGroups, filters, clientOptions)
+		subscriptions, err = managementGroupScanner.ListSubscriptions(ctx, cred, params.ManagementGroups, filters, clientOptions)
 	} else {
 		subscriptionScanner := scanners.SubcriptionScanner{}
-		subscriptions = subscriptionScanner.ListSubscriptions(ctx, cred, params.Subscriptions, filters, clientOptions)
+		subscriptions, err = subscriptionScanner.ListSubscriptions(ctx, cred, params.Subscriptions, filters, clientOptions)
+	}
+	if err != nil {
+		return sc.failed(ctx, &reportData, err)
 	}
 
 	// initialize scanners
 	defenderScanner := scanners.DefenderScanner{}
-	pipScanner := scanners.PublicIPScanner{}
-	peScanner := scanners.PrivateEndpointScanner{}
 	diagnosticsScanner := scanners.DiagnosticSettingsScanner{}
 	advisorScanner := scanners.AdvisorScanner{}
-	costScanner := scanners.CostScanner{}
 	diagResults := map[string]bool{}
 
-	// initialize report data
-	reportData := renderers.NewReportData(outputFile, params.Mask)
-
 	resourceScanner := scanners.ResourceScanner{}
-	reportData.Resources, reportData.ExludedResources = resourceScanner.GetAllResources(ctx, cred, subscriptions, filters)
+	inventory, err := checkpointed(ctx, store, checkpoint.PhaseResources, func() (checkpoint.ResourcesResult, error) {
+		resources, excluded, err := resourceScanner.GetAllResources(ctx, cred, subscriptions, filters, clientOptions)
+		return checkpoint.ResourcesResult{Resources: resources, Excluded: excluded}, err
+	})
+	if err != nil {
+		return sc.failed(ctx, &reportData, err)
+	}
+	reportData.Resources, reportData.ExludedResources = inventory.Resources, inventory.Excluded
 
 	// Check if the number of resources exceeds Excel's row limit (1,048,576 rows) - 10 rows reserved for headers
 	const excelMaxRows = 1048566
 	if len(reportData.Resources) > excelMaxRows {
-		log.Fatal().Msgf("Number of resources (%d) exceeds Excel's maximum row limit (%d). Aborting scan.", len(reportData.Resources), excelMaxRows)
+		return nil, fmt.Errorf("number of resources (%d) exceeds Excel's maximum row limit (%d)", len(reportData.Resources), excelMaxRows)
 	}
 
-	aprlScanner := graph.NewAprlScanner(serviceScanners, filters, subscriptions)
+	// index the inventory, including excluded resources, so rules can query related resources
+	resources := models.NewResourceIndex(reportData.Resources, reportData.ExludedResources)
+
+	aprlScanner := graph.NewAprlScanner(serviceScanners, filters, subscriptions, params.RulePacks)
 	reportData.Recommendations, _ = aprlScanner.ListRecommendations()
 
-	resourceTypes := resourceScanner.GetCountPerResourceType(ctx, cred, subscriptions, filters)
+	resourceTypes, err := resourceScanner.GetCountPerResourceType(ctx, cred, subscriptions, filters, clientOptions)
+	if err != nil {
+		return sc.failed(ctx, &reportData, err)
+	}
 
 	// Filter service scanners to include only those with resource types present in reportData.ResourceTypeCount and count > 0
 	var filteredServiceScanners []models.IAzureScanner
@@ -247,6 +427,12 @@ func (sc Scanner) Scan(params *ScanParams) {
 		for _, resourceType := range s.ResourceTypes() {
 			resourceType = strings.ToLower(resourceType)
 
+			// resource groups are resource containers, not counted in the resources table
+			if resourceType == "microsoft.resources/resourcegroups" {
+				filteredServiceScanners = append(filteredServiceScanners, s)
+				continue
+			}
+
 			// Check if the resource type is in the resourceTypes
 			if count, exists := resourceTypes[resourceType]; !exists || count <= 0 {
 				log.Debug().Msgf("Skipping scanner for resource type %s as it has no resources", resourceType)
@@ -259,147 +445,481 @@ func (sc Scanner) Scan(params *ScanParams) {
 	}
 
 	// get the APRL scan results
-	aprlScanner = graph.NewAprlScanner(filteredServiceScanners, filters, subscriptions)
-	reportData.Aprl = aprlScanner.Scan(ctx, cred)
+	aprlScanner = graph.NewAprlScanner(filteredServiceScanners, filters, subscriptions, params.RulePacks)
+	aprl, err := checkpointed(ctx, store, checkpoint.PhaseAprl, func() (checkpoint.AprlResult, error) {
+		results, scanErrors, err := aprlScanner.Scan(ctx, cred, clientOptions)
+		return checkpoint.AprlResult{Results: results, Errors: scanErrors}, err
+	})
+	if err != nil {
+		return sc.failed(ctx, &reportData, err)
+	}
+	reportData.Aprl, reportData.AprlSuppressed = models.SuppressAprlResults(aprl.Results, resources)
+	reportData.Errors = append(reportData.Errors, aprl.Errors...)
 
 	// get the count of resources per resource type
-	reportData.ResourceTypeCount = resourceScanner.GetCountPerResourceTypeAndSubscription(ctx, cred, subscriptions, reportData.Recommendations, filters)
+	reportData.ResourceTypeCount, err = resourceScanner.GetCountPerResourceTypeAndSubscription(ctx, cred, subscriptions, reportData.Recommendations, filters, clientOptions)
+	if err != nil {
+		return sc.failed(ctx, &reportData, err)
+	}
 
 	// For each service scanner, get the recommendations list
 	if params.UseAzqrRecommendations {
 		for _, s := range serviceScanners {
-			for i, r := range s.GetRecommendations() {
-				if filters.Azqr.IsRecommendationExcluded(r.RecommendationID) {
-					continue
-				}
+			for _, rules := range []map[string]models.AzqrRecommendation{s.GetRecommendations(), customRules.ForResourceTypes(s.ResourceTypes())} {
+				for i, r := range rules {
+					if filters.Azqr.IsRecommendationExcluded(r.RecommendationID) {
+						continue
+					}
 
-				if r.RecommendationType != models.TypeRecommendation {
-					continue
-				}
+					if r.RecommendationType != models.TypeRecommendation {
+						continue
+					}
 
-				if reportData.Recommendations[strings.ToLower(r.ResourceType)] == nil {
-					reportData.Recommendations[strings.ToLower(r.ResourceType)] = map[string]models.AprlRecommendation{}
-				}
+					if reportData.Recommendations[strings.ToLower(r.ResourceType)] == nil {
+						reportData.Recommendations[strings.ToLower(r.ResourceType)] = map[string]models.AprlRecommendation{}
+					}
 
-				reportData.Recommendations[strings.ToLower(r.ResourceType)][i] = r.ToAzureAprlRecommendation()
+					reportData.Recommendations[strings.ToLower(r.ResourceType)][i] = r.ToAzureAprlRecommendation()
+				}
 			}
 		}
 
 		// scan diagnostic settings
 		err := diagnosticsScanner.Init(ctx, cred, clientOptions)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to initialize diagnostic settings scanner")
+			return nil, fmt.Errorf("failed to initialize diagnostic settings scanner: %w", err)
 		}
 
-		diagResults = diagnosticsScanner.Scan(reportData.ResourceIDs())
+		diagResults, err = checkpointed(ctx, store, checkpoint.PhaseDiagnosticSettings, func() (map[string]bool, error) {
+			return diagnosticsScanner.Scan(reportData.ResourceIDs())
+		})
+		if err != nil {
+			addError("Diagnostic Settings", "Microsoft.Insights/diagnosticSettings", err)
+		}
 	}
 
-	// scan each subscription with AZQR scanners
+	// load the subscriptions already scanned from the checkpoint
+	pending := []*models.ScannerConfig{}
 	for sid, sn := range subscriptions {
-		config := &models.ScannerConfig{
+		result, err := store.LoadSubscription(sid)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Ignoring checkpoint of subscription %s", sn)
+		}
+		if result != nil {
+			log.Info().Msgf("Loaded results of subscription %s from checkpoint", sn)
+			sc.addSubscriptionResult(&reportData, result)
+			continue
+		}
+
+		pending = append(pending, &models.ScannerConfig{
 			Ctx:              ctx,
 			SubscriptionID:   sid,
 			SubscriptionName: sn,
 			Cred:             cred,
 			ClientOptions:    clientOptions,
+		})
+	}
+
+	// scan the remaining subscriptions with AZQR scanners
+	for result := range sc.scanSubscriptions(ctx, pending, params, filteredServiceScanners, diagResults, customRules, resources) {
+		sc.addSubscriptionResult(&reportData, result)
+
+		// partially scanned subscriptions are not checkpointed, so they are scanned again on resume
+		if ctx.Err() != nil {
+			continue
+		}
+
+		if err := store.SaveSubscription(result); err != nil {
+			log.Warn().Err(err).Msgf("Failed to checkpoint subscription %s", result.SubscriptionName)
+		}
+	}
+
+	if ctx.Err() != nil {
+		return sc.interrupted(ctx, &reportData)
+	}
+
+	// scan advisor
+	advisorResults, err := checkpointed(ctx, store, checkpoint.PhaseAdvisor, func() ([]models.AdvisorResult, error) {
+		return advisorScanner.Scan(ctx, params.Advisor, cred, subscriptions, filters, clientOptions)
+	})
+	if err != nil {
+		addError("Advisor", "Microsoft.Advisor/recommendations", err)
+	}
+	reportData.Advisor = append(reportData.Advisor, advisorResults...)
+
+	// scan defender
+	defenderResults, err := checkpointed(ctx, store, checkpoint.PhaseDefender, func() ([]models.DefenderResult, error) {
+		return defenderScanner.Scan(ctx, params.Defender, cred, subscriptions, filters, clientOptions)
+	})
+	if err != nil {
+		addError("Defender", "Microsoft.Security/pricings", err)
+	}
+	reportData.Defender = append(reportData.Defender, defenderResults...)
+
+	// get the defender recommendations
+	defenderRecommendations, err := checkpointed(ctx, store, checkpoint.PhaseDefenderRecommendations, func() ([]models.DefenderRecommendation, error) {
+		return defenderScanner.GetRecommendations(ctx, params.Defender, cred, subscriptions, filters, clientOptions)
+	})
+	if err != nil {
+		addError("Defender", "Microsoft.Security/assessments", err)
+	}
+	reportData.DefenderRecommendations = append(reportData.DefenderRecommendations, defenderRecommendations...)
+
+	// waive the findings covered by active exceptions. Expired exceptions are reported with their findings
+	reportData.Aprl, reportData.Exceptions = filters.Azqr.ApplyExceptions(reportData.Azqr, reportData.Aprl, time.Now().UTC())
+
+	if ctx.Err() != nil {
+		return sc.interrupted(ctx, &reportData)
+	}
+
+	if len(reportData.Errors) > 0 {
+		log.Warn().Msgf("%d scanners failed. Check the scan errors in the report for resources that were not assessed", len(reportData.Errors))
+	}
+
+	findings := reportData.Findings()
+	if baseline != nil {
+		reportData.Baseline = baseline.Compare(findings)
+		log.Info().Msgf("Compared to baseline %s: %d new, %d existing and %d resolved findings", params.Baseline, len(reportData.Baseline.New), len(reportData.Baseline.Existing), len(reportData.Baseline.Resolved))
+	}
+
+	if params.WriteBaseline != "" {
+		// the reports are still rendered if the baseline cannot be written
+		if err := models.WriteBaseline(params.WriteBaseline, findings); err != nil {
+			log.Error().Err(err).Msgf("Failed to write baseline %s", params.WriteBaseline)
+			reportData.Errors = append(reportData.Errors, models.ScanError{Scanner: "Baseline", Error: err.Error()})
+		} else {
+			log.Info().Msgf("Baseline with %d findings written to %s", len(findings), params.WriteBaseline)
+		}
+	}
+
+	if params.TrendsFile != "" {
+		// the reports are still rendered if the trend store cannot be written
+		if err := trends.Append(params.TrendsFile, trends.Summarize(&reportData, subscriptions, time.Now())); err != nil {
+			log.Error().Err(err).Msgf("Failed to append scan metrics to %s", params.TrendsFile)
+			reportData.Errors = append(reportData.Errors, models.ScanError{Scanner: "Trends", Error: err.Error()})
+		} else {
+			log.Info().Msgf("Scan metrics appended to %s", params.TrendsFile)
 		}
+	}
+
+	return &reportData, nil
+}
+
+// scanSubscriptions scans up to params.ParallelSubscriptions subscriptions at the same time.
+// All subscriptions share one burst limiter, so the overall ARM request budget is respected.
+// The returned channel is closed once every started subscription finished. No new subscriptions are started once ctx is cancelled.
+func (sc Scanner) scanSubscriptions(ctx context.Context, configs []*models.ScannerConfig, params *ScanParams, serviceScanners []models.IAzureScanner, diagResults map[string]bool, customRules models.CustomRules, resources *models.ResourceIndex) <-chan *checkpoint.SubscriptionResult {
+	workers := params.ParallelSubscriptions
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(configs) {
+		workers = len(configs)
+	}
 
-		if params.UseAzqrRecommendations {
-			// scan private endpoints
-			peResults := peScanner.Scan(config)
+	// Create a burst limiter to control the rate of requests
+	limiter := throttling.NewLimiter(bucketCapacity, refillRate, 1*time.Second, 0*time.Millisecond)
+	burstLimiter := limiter.Start(ctx)
 
-			// scan public IPs
-			pips := pipScanner.Scan(config)
+	jobs := make(chan *models.ScannerConfig)
+	results := make(chan *checkpoint.SubscriptionResult)
 
-			// initialize scan context
-			scanContext := models.ScanContext{
-				Filters:             filters,
-				PrivateEndpoints:    peResults,
-				DiagnosticsSettings: diagResults,
-				PublicIPs:           pips,
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for config := range jobs {
+				results <- sc.scanSubscription(config, params, serviceScanners, diagResults, customRules, resources, burstLimiter)
 			}
+		}()
+	}
 
-			// scan each resource group
-			ch := make(chan []models.AzqrServiceResult, len(filteredServiceScanners))
+	go func() {
+		defer close(jobs)
+		for _, config := range configs {
+			select {
+			case jobs <- config:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
-			// Create a burst limiter to control the rate of requests
-			limiter := throttling.NewLimiter(bucketCapacity, refillRate, 1*time.Second, 0*time.Millisecond)
-			burstLimiter := limiter.Start()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 
-			for _, s := range filteredServiceScanners {
-				err := s.Init(config)
-				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to initialize scanner")
-				}
+	return results
+}
 
-				go func(s models.IAzureScanner) {
-					// Wait for a token from the burstLimiter channel before starting the scan
-					<-burstLimiter
-					res, err := sc.retry(3, 10*time.Millisecond, s, &scanContext)
-					if err != nil {
-						cancel()
-						log.Fatal().Err(err).Msg("Failed to scan")
-					}
-					ch <- res
-				}(s)
+// scanSubscription scans a subscription with the AZQR service scanners and gets its costs
+func (sc Scanner) scanSubscription(config *models.ScannerConfig, params *ScanParams, serviceScanners []models.IAzureScanner, diagResults map[string]bool, customRules models.CustomRules, resources *models.ResourceIndex, burstLimiter <-chan struct{}) *checkpoint.SubscriptionResult {
+	ctx := config.Ctx
+	filters := params.Filters
+	result := &checkpoint.SubscriptionResult{
+		SubscriptionID:   config.SubscriptionID,
+		SubscriptionName: config.SubscriptionName,
+		Azqr:             []models.AzqrServiceResult{},
+		Errors:           []models.ScanError{},
+	}
+
+	// addError records a scanner failure. Failures caused by an interrupted scan are not recorded.
+	addError := func(scanner, resourceType string, err error) {
+		result.Errors = appendScanError(ctx, result.Errors, config.SubscriptionID, config.SubscriptionName, scanner, resourceType, false, err)
+	}
+	// addUnassessed records a failure of a service scanner, whose resources were not assessed
+	addUnassessed := func(s models.IAzureScanner, err error) {
+		result.Errors = appendScanError(ctx, result.Errors, config.SubscriptionID, config.SubscriptionName, models.GetScannerKey(s), strings.Join(s.ResourceTypes(), ", "), true, err)
+	}
+
+	if params.UseAzqrRecommendations {
+		// scan private endpoints
+		peScanner := scanners.PrivateEndpointScanner{}
+		peResults, err := peScanner.Scan(config)
+		if err != nil {
+			addError("Private Endpoints", "Microsoft.Network/privateEndpoints", err)
+			peResults = map[string]bool{}
+		}
+
+		// scan public IPs
+		pipScanner := scanners.PublicIPScanner{}
+		pips, err := pipScanner.Scan(config)
+		if err != nil {
+			addError("Public IPs", "Microsoft.Network/publicIPAddresses", err)
+			pips = map[string]*armnetwork.PublicIPAddress{}
+		}
+
+		// scan resource group tags inherited by resources
+		var rgTags map[string]map[string]*string
+		if filters.Azqr.Tags.Inherits() {
+			rgTagsScanner := scanners.ResourceGroupTagsScanner{}
+			rgTags, err = rgTagsScanner.Scan(config)
+			if err != nil {
+				addError("Resource Group Tags", "Microsoft.Resources/resourceGroups", err)
+				rgTags = map[string]map[string]*string{}
 			}
+		}
 
-			for i := 0; i < len(filteredServiceScanners); i++ {
-				res := <-ch
-				for _, r := range res {
-					// check if the resource is excluded
-					if filters.Azqr.IsServiceExcluded(r.ResourceID()) {
-						continue
-					}
-					reportData.Azqr = append(reportData.Azqr, r)
+		// initialize scan context
+		scanContext := models.ScanContext{
+			Filters:             filters,
+			PrivateEndpoints:    peResults,
+			DiagnosticsSettings: diagResults,
+			PublicIPs:           pips,
+			CustomRules:         customRules,
+			ResourceGroupTags:   rgTags,
+			Resources:           resources,
+		}
+
+		// scan each resource group
+		ch := make(chan serviceScanResult, len(serviceScanners))
+
+		started := 0
+		for _, s := range serviceScanners {
+			s = models.NewScannerInstance(s)
+			err := s.Init(config)
+			if err != nil {
+				addUnassessed(s, err)
+				continue
+			}
+
+			started++
+			go func(s models.IAzureScanner) {
+				// Wait for a token from the burstLimiter channel before starting the scan
+				select {
+				case <-burstLimiter:
+				case <-ctx.Done():
+					ch <- serviceScanResult{scanner: s, err: ctx.Err()}
+					return
 				}
+				res, err := sc.retry(3, 10*time.Millisecond, s, &scanContext)
+				ch <- serviceScanResult{scanner: s, results: res, err: err}
+			}(s)
+		}
+
+		for i := 0; i < started; i++ {
+			res := <-ch
+			if res.err != nil {
+				addUnassessed(res.scanner, res.err)
+				continue
+			}
+			for _, r := range res.results {
+				// check if the resource is excluded
+				if filters.Azqr.IsServiceExcluded(r.ResourceID()) {
+					continue
+				}
+				result.Azqr = append(result.Azqr, r)
 			}
 		}
+	}
 
-		// scan costs
-		costs := costScanner.Scan(params.Cost, config)
-		reportData.Cost.From = costs.From
-		reportData.Cost.To = costs.To
-		reportData.Cost.Items = append(reportData.Cost.Items, costs.Items...)
+	// scan costs
+	costScanner := scanners.CostScanner{}
+	costs, err := costScanner.Scan(params.Cost, config)
+	if err != nil {
+		addError("Costs", "Microsoft.CostManagement/query", err)
+		return result
 	}
+	result.Cost = costs
 
-	// scan advisor
-	reportData.Advisor = append(reportData.Advisor, advisorScanner.Scan(ctx, params.Defender, cred, subscriptions, filters)...)
+	return result
+}
 
-	// scan defender
-	reportData.Defender = append(reportData.Defender, defenderScanner.Scan(ctx, params.Defender, cred, subscriptions, filters)...)
+// addSubscriptionResult adds the results of a subscription scan to the report data
+func (sc Scanner) addSubscriptionResult(reportData *renderers.ReportData, result *checkpoint.SubscriptionResult) {
+	reportData.Azqr = append(reportData.Azqr, result.Azqr...)
+	reportData.Errors = append(reportData.Errors, result.Errors...)
+	if result.Cost != nil {
+		reportData.Cost.From = result.Cost.From
+		reportData.Cost.To = result.Cost.To
+		reportData.Cost.Items = append(reportData.Cost.Items, result.Cost.Items...)
+	}
+}
 
-	// get the defender recommendations
-	reportData.DefenderRecommendations = append(reportData.DefenderRecommendations, defenderScanner.GetRecommendations(ctx, params.Defender, cred, subscriptions, filters)...)
+// openCheckpoint opens the checkpoint store selected in params. Returns nil if checkpoints are disabled.
+func (sc Scanner) openCheckpoint(params *ScanParams) (*checkpoint.Store, error) {
+	if params.CheckpointDir == "" {
+		if params.Resume {
+			return nil, errors.New("a checkpoint directory is required to resume a scan")
+		}
+		return nil, nil
+	}
+
+	store, err := checkpoint.NewStore(params.CheckpointDir)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := checkpoint.Manifest{
+		ManagementGroups: params.ManagementGroups,
+		Subscriptions:    params.Subscriptions,
+		ResourceGroups:   params.ResourceGroups,
+		ScannerKeys:      params.ScannerKeys,
+		Filters:          checkpoint.HashFilters(params.Filters),
+		Cloud:            params.Cloud,
+		Defender:         params.Defender,
+		Advisor:          params.Advisor,
+		Cost:             params.Cost,
+		Azqr:             params.UseAzqrRecommendations,
+		Aprl:             params.UseAprlRecommendations,
+		RulesDir:         params.RulesDir,
+		RulePacks:        params.RulePacks,
+	}
+	if err := store.Open(manifest, params.Resume); err != nil {
+		return nil, err
+	}
+
+	if params.Resume {
+		log.Info().Msgf("Resuming scan from checkpoint %s", params.CheckpointDir)
+	}
+	return store, nil
+}
+
+// checkpointed loads the results of a tenant wide phase from the checkpoint store, or runs scan and checkpoints its results.
+// Failed or interrupted scans are not checkpointed, so they run again on resume.
+func checkpointed[T any](ctx context.Context, store *checkpoint.Store, phase checkpoint.Phase, scan func() (T, error)) (T, error) {
+	var results T
+	found, err := store.LoadPhase(phase, &results)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Ignoring %s checkpoint", phase)
+	}
+	if found {
+		log.Info().Msgf("Loaded %s results from checkpoint", phase)
+		return results, nil
+	}
+
+	results, err = scan()
+	if err != nil || ctx.Err() != nil {
+		return results, err
+	}
+
+	if err := store.SavePhase(phase, results); err != nil {
+		log.Warn().Err(err).Msgf("Failed to checkpoint %s results", phase)
+	}
+	return results, nil
+}
+
+// logThrottlingMetrics logs how much the scan was throttled by Azure Resource Manager
+func (sc Scanner) logThrottlingMetrics(limiter *throttling.AdaptiveLimiter) {
+	m := limiter.Metrics()
+	log.Info().Msgf("ARM requests: %d, throttled: %d, delayed: %d (%s)", m.Requests, m.Throttled, m.Delayed, m.TotalDelay.Round(time.Second))
+	for scope, remaining := range m.MinRemaining {
+		log.Debug().Msgf("Lowest remaining ARM reads for %s: %d", scope, remaining)
+	}
+}
+
+// appendScanError records a scanner failure. Failures caused by an interrupted scan are not recorded.
+func appendScanError(ctx context.Context, scanErrors []models.ScanError, subscriptionID, subscriptionName, scanner, resourceType string, unassessed bool, err error) []models.ScanError {
+	if ctx.Err() != nil {
+		return scanErrors
+	}
+	scanError := models.NewScanError(subscriptionID, subscriptionName, scanner, resourceType, err)
+	scanError.Unassessed = unassessed
+	return append(scanErrors, scanError)
+}
 
+// failed returns the partial report data if the scan was interrupted, otherwise the error
+func (sc Scanner) failed(ctx context.Context, reportData *renderers.ReportData, err error) (*renderers.ReportData, error) {
+	if ctx.Err() != nil {
+		return sc.interrupted(ctx, reportData)
+	}
+	return nil, err
+}
+
+// interrupted marks the report data collected so far as incomplete
+func (sc Scanner) interrupted(ctx context.Context, reportData *renderers.ReportData) (*renderers.ReportData, error) {
+	log.Warn().Msg("Scan interrupted. Reports will only contain the results collected so far")
+	reportData.Incomplete = true
+	reportData.OutputFileName = fmt.Sprintf("%s_incomplete", reportData.OutputFileName)
+	reportData.Errors = append(reportData.Errors, models.ScanError{
+		Scanner:    "azqr",
+		Error:      "Scan interrupted before completion. Results are incomplete",
+		Unassessed: true,
+	})
+	return reportData, ctx.Err()
+}
+
+// Render creates the reports selected in params from the report data
+func (sc Scanner) Render(reportData *renderers.ReportData, params *ScanParams) error {
 	if params.Xlsx {
 		// render excel report
-		excel.CreateExcelReport(&reportData)
+		if err := excel.CreateExcelReport(reportData); err != nil {
+			return err
+		}
 	}
 
 	// render json report
 	if params.Json {
-		json.CreateJsonReport(&reportData)
+		if err := json.CreateJsonReport(reportData); err != nil {
+			return err
+		}
 	}
 
 	// render csv reports
 	if params.Csv {
-		csv.CreateCsvReport(&reportData)
+		if err := csv.CreateCsvReport(reportData); err != nil {
+			return err
+		}
 	}
 
-	elapsedTime := time.Since(startTime)
-	// Format the elapsed time as HH:MM:SS and log the scan completion time
-	hours := int(elapsedTime.Hours())
-	minutes := int(elapsedTime.Minutes()) % 60
-	seconds := int(elapsedTime.Seconds()) % 60
-	log.Info().Msgf("Scan completed in %02d:%02d:%02d", hours, minutes, seconds)
+	// render sarif report
+	if params.Sarif {
+		if err := sarif.CreateSarifReport(reportData); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // retry retries the Azure scanner Scan, a number of times with an increasing delay between retries
 func (sc Scanner) retry(attempts int, sleep time.Duration, a models.IAzureScanner, scanContext *models.ScanContext) ([]models.AzqrServiceResult, error) {
 	var err error
 	for i := 0; ; i++ {
-		res, err := a.Scan(scanContext)
+		var res []models.AzqrServiceResult
+		res, err = a.Scan(scanContext)
 		if err == nil {
 			return res, nil
 		}
@@ -423,21 +943,22 @@ func (sc Scanner) retry(attempts int, sleep time.Duration, a models.IAzureScanne
 	return nil, err
 }
 
-func (sc Scanner) newAzureCredential(forceAzureCliCredential bool) azcore.TokenCredential {
-	var cred azcore.TokenCredential
-	var err error
+func (sc Scanner) newAzureCredential(forceAzureCliCredential bool, cloudConfig cloud.Configuration) (azcore.TokenCredential, error) {
 	if !forceAzureCliCredential {
-		cred, err = azidentity.NewDefaultAzureCredential(nil)
-		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to get Azure credentials")
-		}
-	} else {
-		cred, err = azidentity.NewAzureCLICredential(nil)
+		cred, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			ClientOptions: policy.ClientOptions{Cloud: cloudConfig},
+		})
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to get Azure CLI credentials")
+			return nil, fmt.Errorf("failed to get Azure credentials: %w", err)
 		}
+		return cred, nil
+	}
+
+	cred, err := azidentity.NewAzureCLICredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Azure CLI credentials: %w", err)
 	}
-	return cred
+	return cred, nil
 }
 
 func (sc Scanner) generateOutputFileName(outputName string) string {
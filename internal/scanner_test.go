@@ -0,0 +1,300 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package internal
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/recording"
+	"github.com/Azure/azqr/internal/renderers/json"
+	"github.com/Azure/azqr/internal/testserver"
+)
+
+var update = flag.Bool("update", false, "record testdata/scan.jsonl again against the test server seeded with testdata/scan_seed.yaml")
+
+const (
+	replaySeed    = "testdata/scan_seed.yaml"
+	replayFixture = "testdata/scan.jsonl"
+	// replaySubscriptionID is the scrubbed ID of the recorded subscription
+	replaySubscriptionID = "00000000-0000-0000-0000-000000000001"
+)
+
+func replayParams(t *testing.T) *ScanParams {
+	t.Helper()
+	params := NewScanParams()
+	params.Subscriptions = []string{replaySubscriptionID}
+	params.ScannerKeys = []string{"aks", "st", "pip"}
+	params.UseAzqrRecommendations = true
+	params.UseAprlRecommendations = true
+	// the cost query depends on the current date
+	params.Cost = false
+	params.OutputName = filepath.Join(t.TempDir(), "replay")
+
+	filters, err := models.LoadFilters("", params.ScannerKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.Filters = filters
+	return params
+}
+
+// record scans the test server and records the exchanges to the fixture bundle
+func record(t *testing.T) {
+	t.Helper()
+	server, err := testserver.NewFromFile(replaySeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	recorder, err := recording.NewRecorder(replayFixture, server.Transport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := replayParams(t)
+	params.Transport = recorder
+	params.Credential = server.Credential()
+
+	_, err = Scanner{}.ScanReport(context.Background(), params)
+	if cerr := recorder.Close(); cerr != nil {
+		t.Fatal(cerr)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestScanReport_Replay replays a recorded scan offline, through the scanners, the Resource Graph queries and the renderers
+func TestScanReport_Replay(t *testing.T) {
+	if *update {
+		record(t)
+	}
+
+	replayer, err := recording.NewReplayer(replayFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := replayParams(t)
+	params.Transport = replayer
+	params.Credential = recording.FakeCredential{}
+
+	data, err := Scanner{}.ScanReport(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data.Errors) != 0 {
+		t.Errorf("replayed scan has errors: %v", data.Errors)
+	}
+	if len(data.Resources) != 4 {
+		t.Errorf("replayed inventory has %d resources, want 4", len(data.Resources))
+	}
+
+	// AZQR findings
+	findings := map[string]bool{}
+	for _, d := range data.Azqr {
+		for _, r := range d.Recommendations {
+			findings[d.ServiceName+"/"+r.RecommendationID] = r.NotCompliant
+		}
+	}
+	for finding, want := range map[string]bool{
+		"aks-prod/aks-003":    false,
+		"aks-prod/aks-004":    false,
+		"public-free/aks-003": true,
+		"public-free/aks-004": true,
+		"stprod/st-011":       false,
+	} {
+		got, ok := findings[finding]
+		if !ok {
+			t.Errorf("%s was not evaluated", finding)
+		} else if got != want {
+			t.Errorf("%s not compliant = %v, want %v", finding, got, want)
+		}
+	}
+
+	// APRL findings of the Resource Graph queries
+	if len(data.Aprl) != 1 || data.Aprl[0].Name != "pip-unused" || data.Aprl[0].Recommendation == "" {
+		t.Errorf("Aprl = %+v, want the orphaned public IP pip-unused", data.Aprl)
+	}
+
+	// rendered tables
+	impacted := data.ImpactedTable()
+	rows := map[string]bool{}
+	for _, row := range impacted[1:] {
+		rows[row[10]+"/"+row[6]] = true
+	}
+	for _, want := range []string{"public-free/aks-003", "public-free/aks-004", "pip-unused/5e6f7a8b-9c0d-1e2f-3a4b-5c6d7e8f9a0b"} {
+		if !rows[want] {
+			t.Errorf("impacted table has no row for %s", want)
+		}
+	}
+	if rows["aks-prod/aks-004"] {
+		t.Error("impacted table has a row for the compliant aks-prod/aks-004")
+	}
+
+	if err := json.CreateJsonReport(data); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(params.OutputName + ".impacted.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "public-free") || strings.Contains(string(content), replaySubscriptionID) {
+		t.Errorf("impacted.json must list public-free with masked subscription IDs, got %s", content)
+	}
+}
+
+const resourceGroupsSeed = `
+subscriptions:
+  - id: 00000000-0000-0000-0000-000000000001
+    name: test
+resourceGroups:
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg-tagged
+    location: westeurope
+    tags:
+      environment: prod
+resources:
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg-untagged/providers/Microsoft.Network/publicIPAddresses/pip
+    location: westeurope
+`
+
+// TestScanReport_ResourceGroups scans resource groups, which Resource Graph does not count with the resources
+func TestScanReport_ResourceGroups(t *testing.T) {
+	seed, err := testserver.ParseSeed([]byte(resourceGroupsSeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := testserver.New(seed)
+	defer server.Close()
+
+	params := replayParams(t)
+	params.ScannerKeys = []string{"rg"}
+	params.UseAprlRecommendations = false
+	params.Transport = server.Transport()
+	params.Credential = server.Credential()
+	filters, err := models.LoadFilters("", params.ScannerKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.Filters = filters
+
+	data, err := Scanner{}.ScanReport(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := map[string]bool{}
+	for _, d := range data.Azqr {
+		if r, ok := d.Recommendations["rg-001"]; ok {
+			findings[d.ServiceName] = r.NotCompliant
+		}
+	}
+	if len(findings) != 2 || findings["rg-tagged"] || !findings["rg-untagged"] {
+		t.Errorf("rg-001 not compliant = %v, want rg-tagged compliant and rg-untagged not compliant", findings)
+	}
+}
+
+// TestScanReport_OutputFails checks the report of a complete scan is returned even if the baseline or the trend store cannot be written
+func TestScanReport_OutputFails(t *testing.T) {
+	seed, err := testserver.ParseSeed([]byte(resourceGroupsSeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := testserver.New(seed)
+	defer server.Close()
+
+	params := replayParams(t)
+	params.ScannerKeys = []string{"rg"}
+	params.UseAprlRecommendations = false
+	params.Transport = server.Transport()
+	params.Credential = server.Credential()
+	missing := filepath.Join(t.TempDir(), "missing")
+	params.WriteBaseline = filepath.Join(missing, "baseline.json")
+	params.TrendsFile = filepath.Join(missing, "trends.jsonl")
+	filters, err := models.LoadFilters("", params.ScannerKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.Filters = filters
+
+	data, err := Scanner{}.ScanReport(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Azqr) == 0 {
+		t.Error("got no AZQR results, want the results of the scan")
+	}
+	if len(data.Errors) != 2 || data.Errors[0].Scanner != "Baseline" || data.Errors[1].Scanner != "Trends" {
+		t.Errorf("Errors = %v, want the failures writing the baseline and the trend store", data.Errors)
+	}
+	for _, e := range data.Errors {
+		if e.Unassessed {
+			t.Errorf("%s error leaves resources unassessed, want false", e.Scanner)
+		}
+	}
+}
+
+const advisorSeed = `
+subscriptions:
+  - id: 00000000-0000-0000-0000-000000000001
+    name: test
+resources:
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/pip
+    location: westeurope
+graph:
+  - query: AdvisorResources
+    data:
+      - SubscriptionId: 00000000-0000-0000-0000-000000000001
+        SubscriptionName: test
+        ResourceId: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/pip
+        Category: Cost
+        Impact: Low
+        Problem: Delete the unused public IP address
+        RecommendationTypeId: 00000000-0000-0000-0000-00000000000a
+`
+
+// TestScanReport_Advisor checks the Advisor recommendations are scanned with the Advisor option, not the Defender one
+func TestScanReport_Advisor(t *testing.T) {
+	seed, err := testserver.ParseSeed([]byte(advisorSeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := testserver.New(seed)
+	defer server.Close()
+
+	for _, tt := range []struct {
+		advisor, defender bool
+		want              int
+	}{
+		{advisor: true, defender: false, want: 1},
+		{advisor: false, defender: true, want: 0},
+	} {
+		params := replayParams(t)
+		params.ScannerKeys = []string{"pip"}
+		params.UseAprlRecommendations = false
+		params.Advisor = tt.advisor
+		params.Defender = tt.defender
+		params.Transport = server.Transport()
+		params.Credential = server.Credential()
+		filters, err := models.LoadFilters("", params.ScannerKeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		params.Filters = filters
+
+		data, err := Scanner{}.ScanReport(context.Background(), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data.Advisor) != tt.want {
+			t.Errorf("with advisor %v and defender %v, got %d Advisor recommendations, want %d", tt.advisor, tt.defender, len(data.Advisor), tt.want)
+		}
+	}
+}
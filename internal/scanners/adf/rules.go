@@ -24,6 +24,7 @@ func (a *DataFactoryScanner) GetRecommendations() map[string]models.AzqrRecommen
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/data-factory/monitor-configure-diagnostics",
 		},
 		"adf-002": {
@@ -37,6 +38,7 @@ func (a *DataFactoryScanner) GetRecommendations() map[string]models.AzqrRecommen
 				_, pe := scanContext.PrivateEndpoints[*i.ID]
 				return !pe, ""
 			},
+			Evidence: []string{"scanContext.PrivateEndpoints"},
 		},
 		"adf-003": {
 			RecommendationID:   "adf-003",
@@ -58,9 +60,9 @@ func (a *DataFactoryScanner) GetRecommendations() map[string]models.AzqrRecommen
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armdatafactory.Factory)
-				caf := strings.HasPrefix(*c.Name, "adf")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.DataFactory/factories", "adf", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"adf-005": {
@@ -71,8 +73,9 @@ func (a *DataFactoryScanner) GetRecommendations() map[string]models.AzqrRecommen
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armdatafactory.Factory)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -10,18 +10,22 @@ import (
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/rs/zerolog/log"
 )
 
 // AdvisorScanner - Advisor scanner
 type AdvisorScanner struct{}
 
-func (s *AdvisorScanner) Scan(ctx context.Context, scan bool, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters) []models.AdvisorResult {
+func (s *AdvisorScanner) Scan(ctx context.Context, scan bool, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters, options *arm.ClientOptions) ([]models.AdvisorResult, error) {
 	models.LogResourceTypeScan("Advisor Recommendations")
 	resources := []models.AdvisorResult{}
 
 	if scan {
-		graphClient := graph.NewGraphQuery(cred)
+		graphClient, err := graph.NewGraphQuery(cred, options)
+		if err != nil {
+			return nil, err
+		}
 		query := `
 		AdvisorResources
 		| join kind=inner (
@@ -41,7 +45,10 @@ func (s *AdvisorScanner) Scan(ctx context.Context, scan bool, cred azcore.TokenC
 		for s := range subscriptions {
 			subs = append(subs, &s)
 		}
-		result := graphClient.Query(ctx, query, subs)
+		result, err := graphClient.Query(ctx, query, subs)
+		if err != nil {
+			return nil, err
+		}
 		resources = []models.AdvisorResult{}
 		if result.Data != nil {
 			for _, row := range result.Data {
@@ -70,5 +77,5 @@ func (s *AdvisorScanner) Scan(ctx context.Context, scan bool, cred azcore.TokenC
 			}
 		}
 	}
-	return resources
+	return resources, nil
 }
@@ -24,6 +24,7 @@ func (a *FrontDoorScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/frontdoor/standard-premium/how-to-logs",
 		},
 		"afd-003": {
@@ -46,9 +47,9 @@ func (a *FrontDoorScanner) GetRecommendations() map[string]models.AzqrRecommenda
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcdn.Profile)
-				caf := strings.HasPrefix(*c.Name, "afd")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Cdn/profiles", "afd", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"afd-007": {
@@ -59,8 +60,9 @@ func (a *FrontDoorScanner) GetRecommendations() map[string]models.AzqrRecommenda
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcdn.Profile)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
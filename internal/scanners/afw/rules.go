@@ -23,6 +23,7 @@ func (a *FirewallScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://docs.microsoft.com/en-us/azure/firewall/logs-and-metrics",
 		},
 		"afw-003": {
@@ -41,6 +42,7 @@ func (a *FirewallScanner) GetRecommendations() map[string]models.AzqrRecommendat
 
 				return false, sla
 			},
+			Evidence:     []string{"Zones"},
 			LearnMoreUrl: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
 		"afw-006": {
@@ -51,9 +53,9 @@ func (a *FirewallScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.AzureFirewall)
-				caf := strings.HasPrefix(*c.Name, "afw")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Network/azureFirewalls", "afw", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"afw-007": {
@@ -64,8 +66,9 @@ func (a *FirewallScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.AzureFirewall)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
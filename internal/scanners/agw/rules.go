@@ -24,6 +24,7 @@ func (a *ApplicationGatewayScanner) GetRecommendations() map[string]models.AzqrR
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/application-gateway/application-gateway-diagnostics#diagnostic-logging",
 		},
 		"agw-103": {
@@ -46,9 +47,9 @@ func (a *ApplicationGatewayScanner) GetRecommendations() map[string]models.AzqrR
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				g := target.(*armnetwork.ApplicationGateway)
-				caf := strings.HasPrefix(*g.Name, "agw")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Network/applicationGateways", "agw", *g.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"agw-106": {
@@ -59,8 +60,9 @@ func (a *ApplicationGatewayScanner) GetRecommendations() map[string]models.AzqrR
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.ApplicationGateway)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
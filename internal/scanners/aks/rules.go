@@ -25,6 +25,7 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/aks/monitor-aks#collect-resource-logs",
 		},
 		"aks-003": {
@@ -58,6 +59,7 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 				}
 				return sla == "None", sla
 			},
+			Evidence:     []string{"Properties.AgentPoolProfiles", "SKU.Tier"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/aks/free-standard-pricing-tiers#uptime-sla-terms-and-conditions",
 		},
 		"aks-004": {
@@ -71,6 +73,7 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 				pe := c.Properties.APIServerAccessProfile != nil && c.Properties.APIServerAccessProfile.EnablePrivateCluster != nil && *c.Properties.APIServerAccessProfile.EnablePrivateCluster
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.APIServerAccessProfile.EnablePrivateCluster"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/aks/private-clusters",
 		},
 		"aks-006": {
@@ -81,9 +84,9 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcontainerservice.ManagedCluster)
-				caf := strings.HasPrefix(*c.Name, "aks")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.ContainerService/managedClusters", "aks", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"aks-007": {
@@ -97,6 +100,7 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 				aad := c.Properties.AADProfile != nil && c.Properties.AADProfile.Managed != nil && *c.Properties.AADProfile.Managed
 				return !aad, ""
 			},
+			Evidence:     []string{"Properties.AADProfile.Managed"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/aks/managed-azure-ad",
 		},
 		"aks-008": {
@@ -107,9 +111,10 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactMedium,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcontainerservice.ManagedCluster)
-				rbac := *c.Properties.EnableRBAC
+				rbac := c.Properties.EnableRBAC != nil && *c.Properties.EnableRBAC
 				return !rbac, ""
 			},
+			Evidence:     []string{"Properties.EnableRBAC"},
 			LearnMoreUrl: "https://learn.microsoft.com/azure/aks/manage-azure-rbac",
 		},
 		"aks-010": {
@@ -124,6 +129,7 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 				broken := exists && *p.Enabled
 				return broken, ""
 			},
+			Evidence:     []string{"Properties.AddonProfiles"},
 			LearnMoreUrl: "https://learn.microsoft.com/azure/aks/http-application-routing",
 		},
 		"aks-012": {
@@ -134,9 +140,10 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactHigh,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcontainerservice.ManagedCluster)
-				broken := c.Properties.NetworkProfile.OutboundType == nil || *c.Properties.NetworkProfile.OutboundType != armcontainerservice.OutboundTypeUserDefinedRouting
+				broken := c.Properties.NetworkProfile == nil || c.Properties.NetworkProfile.OutboundType == nil || *c.Properties.NetworkProfile.OutboundType != armcontainerservice.OutboundTypeUserDefinedRouting
 				return broken, ""
 			},
+			Evidence:     []string{"Properties.NetworkProfile.OutboundType"},
 			LearnMoreUrl: "https://learn.microsoft.com/azure/aks/limit-egress-traffic",
 		},
 		"aks-015": {
@@ -147,8 +154,9 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcontainerservice.ManagedCluster)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"aks-016": {
@@ -168,6 +176,7 @@ func (a *AKSScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 				}
 				return defaultMaxSurge, ""
 			},
+			Evidence:     []string{"Properties.AgentPoolProfiles"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/aks/operator-best-practices-run-at-scale#cluster-upgrade-considerations-and-best-practices",
 		},
 	}
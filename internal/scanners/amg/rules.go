@@ -21,9 +21,9 @@ func (a *ManagedGrafanaScanner) GetRecommendations() map[string]models.AzqrRecom
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armdashboard.ManagedGrafana)
-				caf := strings.HasPrefix(*c.Name, "amg")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Dashboard/managedGrafana", "amg", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"amg-002": {
@@ -45,6 +45,7 @@ func (a *ManagedGrafanaScanner) GetRecommendations() map[string]models.AzqrRecom
 				}
 				return sla == "None", sla
 			},
+			Evidence:     []string{"SKU.Name"},
 			LearnMoreUrl: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
 		"amg-003": {
@@ -55,8 +56,9 @@ func (a *ManagedGrafanaScanner) GetRecommendations() map[string]models.AzqrRecom
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armdashboard.ManagedGrafana)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"amg-004": {
@@ -69,6 +71,7 @@ func (a *ManagedGrafanaScanner) GetRecommendations() map[string]models.AzqrRecom
 				c := target.(*armdashboard.ManagedGrafana)
 				return *c.Properties.PublicNetworkAccess == armdashboard.PublicNetworkAccessEnabled, ""
 			},
+			Evidence:     []string{"Properties.PublicNetworkAccess"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/security/benchmark/azure/baselines/azure-synapse-analytics-security-baseline?toc=%2Fazure%2Fsynapse-analytics%2Ftoc.json",
 		},
 		"amg-005": {
@@ -81,6 +84,11 @@ func (a *ManagedGrafanaScanner) GetRecommendations() map[string]models.AzqrRecom
 				c := target.(*armdashboard.ManagedGrafana)
 				return *c.Properties.ZoneRedundancy == armdashboard.ZoneRedundancyDisabled, ""
 			},
+			Evidence: []string{"Properties.ZoneRedundancy"},
+			Applies: func(target interface{}, scanContext *models.ScanContext) bool {
+				c := target.(*armdashboard.ManagedGrafana)
+				return models.HasAvailabilityZones(c.Location)
+			},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/managed-grafana/high-availability",
 		},
 	}
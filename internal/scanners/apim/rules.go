@@ -25,6 +25,7 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-use-azure-monitor#resource-logs",
 		},
 		"apim-003": {
@@ -46,6 +47,7 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 
 				return sla == "None", sla
 			},
+			Evidence:     []string{"SKU.Name", "Zones", "Properties.AdditionalLocations"},
 			LearnMoreUrl: "https://www.azure.cn/en-us/support/sla/api-management/",
 		},
 		"apim-004": {
@@ -59,6 +61,7 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 				pe := len(a.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/api-management/private-endpoint",
 		},
 		"apim-006": {
@@ -69,9 +72,9 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armapimanagement.ServiceResource)
-				caf := strings.HasPrefix(*c.Name, "apim")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.ApiManagement/service", "apim", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"apim-007": {
@@ -82,8 +85,9 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armapimanagement.ServiceResource)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"apim-008": {
@@ -96,6 +100,7 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 				c := target.(*armapimanagement.ServiceResource)
 				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armapimanagement.ApimIdentityTypeNone, ""
 			},
+			Evidence:     []string{"Identity.Type"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-use-managed-service-identity",
 		},
 		"apim-009": {
@@ -128,6 +133,7 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 
 				return false, ""
 			},
+			Evidence:     []string{"Properties.CustomProperties"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-manage-protocols-ciphers",
 		},
 		"apim-010": {
@@ -162,6 +168,7 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 
 				return false, ""
 			},
+			Evidence:     []string{"Properties.CustomProperties"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-manage-protocols-ciphers",
 		},
 		"apim-011": {
@@ -184,6 +191,7 @@ func (a *APIManagementScanner) GetRecommendations() map[string]models.AzqrRecomm
 				}
 				return false, ""
 			},
+			Evidence:     []string{"Properties.HostnameConfigurations"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/api-management/configure-custom-domain?tabs=custom",
 		},
 	}
@@ -24,6 +24,7 @@ func (a *AppConfigurationScanner) GetRecommendations() map[string]models.AzqrRec
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-app-configuration/monitor-app-configuration?tabs=portal",
 		},
 		"appcs-003": {
@@ -43,6 +44,7 @@ func (a *AppConfigurationScanner) GetRecommendations() map[string]models.AzqrRec
 
 				return sla == "None", sla
 			},
+			Evidence:     []string{"SKU.Name"},
 			LearnMoreUrl: "https://www.azure.cn/en-us/support/sla/app-configuration/",
 		},
 		"appcs-004": {
@@ -56,6 +58,7 @@ func (a *AppConfigurationScanner) GetRecommendations() map[string]models.AzqrRec
 				pe := len(a.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-app-configuration/concept-private-endpoint",
 		},
 		"appcs-006": {
@@ -66,9 +69,9 @@ func (a *AppConfigurationScanner) GetRecommendations() map[string]models.AzqrRec
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappconfiguration.ConfigurationStore)
-				caf := strings.HasPrefix(*c.Name, "appcs")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.AppConfiguration/configurationStores", "appcs", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"appcs-007": {
@@ -79,8 +82,9 @@ func (a *AppConfigurationScanner) GetRecommendations() map[string]models.AzqrRec
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappconfiguration.ConfigurationStore)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"appcs-008": {
@@ -94,6 +98,7 @@ func (a *AppConfigurationScanner) GetRecommendations() map[string]models.AzqrRec
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
 				return !localAuth, ""
 			},
+			Evidence:     []string{"Properties.DisableLocalAuth"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-app-configuration/howto-disable-access-key-authentication?tabs=portal#disable-access-key-authentication",
 		},
 	}
@@ -4,8 +4,6 @@
 package appi
 
 import (
-	"strings"
-
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/applicationinsights/armapplicationinsights"
 )
@@ -33,9 +31,9 @@ func (a *AppInsightsScanner) GetRecommendations() map[string]models.AzqrRecommen
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armapplicationinsights.Component)
-				caf := strings.HasPrefix(*c.Name, "appi")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Insights/components", "appi", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"appi-003": {
@@ -46,8 +44,9 @@ func (a *AppInsightsScanner) GetRecommendations() map[string]models.AzqrRecommen
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armapplicationinsights.Component)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
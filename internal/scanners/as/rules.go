@@ -24,6 +24,7 @@ func (a *AnalysisServicesScanner) GetRecommendations() map[string]models.AzqrRec
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/analysis-services/analysis-services-logging",
 		},
 		"as-002": {
@@ -42,6 +43,7 @@ func (a *AnalysisServicesScanner) GetRecommendations() map[string]models.AzqrRec
 				}
 				return sla == "None", sla
 			},
+			Evidence:     []string{"SKU.Tier"},
 			LearnMoreUrl: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
 		"as-004": {
@@ -52,9 +54,9 @@ func (a *AnalysisServicesScanner) GetRecommendations() map[string]models.AzqrRec
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armanalysisservices.Server)
-				caf := strings.HasPrefix(*c.Name, "as")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.AnalysisServices/servers", "as", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"as-005": {
@@ -65,8 +67,9 @@ func (a *AnalysisServicesScanner) GetRecommendations() map[string]models.AzqrRec
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armanalysisservices.Server)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -76,14 +76,14 @@ func (a *AppServiceScanner) Scan(scanContext *models.ScanContext) ([]models.Azqr
 			if err != nil {
 				return nil, err
 			}
-			scanContext.SiteConfig = &config
+			siteContext := scanContext.WithResource(models.ResourceData{SiteConfig: &config})
 
 			var result models.AzqrServiceResult
 			// https://learn.microsoft.com/en-us/azure/azure-functions/functions-app-settings
 			kind := strings.ToLower(*s.Kind)
 			switch kind {
 			case "functionapp,linux", "functionapp":
-				rr := engine.EvaluateRecommendations(functionRules, s, scanContext)
+				rr := engine.EvaluateRecommendations(functionRules, s, siteContext)
 
 				result = models.AzqrServiceResult{
 					SubscriptionID:   a.config.SubscriptionID,
@@ -95,7 +95,7 @@ func (a *AppServiceScanner) Scan(scanContext *models.ScanContext) ([]models.Azqr
 					Recommendations:  rr,
 				}
 			case "functionapp,workflowapp":
-				rr := engine.EvaluateRecommendations(logicRules, s, scanContext)
+				rr := engine.EvaluateRecommendations(logicRules, s, siteContext)
 
 				result = models.AzqrServiceResult{
 					SubscriptionID:   a.config.SubscriptionID,
@@ -107,7 +107,7 @@ func (a *AppServiceScanner) Scan(scanContext *models.ScanContext) ([]models.Azqr
 					Recommendations:  rr,
 				}
 			default:
-				rr := engine.EvaluateRecommendations(appRules, s, scanContext)
+				rr := engine.EvaluateRecommendations(appRules, s, siteContext)
 				result = models.AzqrServiceResult{
 					SubscriptionID:   a.config.SubscriptionID,
 					SubscriptionName: a.config.SubscriptionName,
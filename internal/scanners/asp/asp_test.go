@@ -0,0 +1,118 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package asp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/testserver"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+const seed = `
+subscriptions:
+  - id: 00000000-0000-0000-0000-000000000001
+    name: test
+resources:
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan
+    location: westeurope
+    sku:
+      name: P1v3
+      tier: PremiumV3
+      capacity: 3
+    properties:
+      zoneRedundant: true
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Web/sites/tls12
+    location: westeurope
+    kind: app
+    properties:
+      resourceGroup: rg
+      serverFarmId: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Web/sites/tls12/config/web
+    properties:
+      minTlsVersion: "1.2"
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Web/sites/tls10
+    location: westeurope
+    kind: app
+    properties:
+      resourceGroup: rg
+      serverFarmId: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Web/sites/tls10/config/web
+    properties:
+      minTlsVersion: "1.0"
+`
+
+// TestAppServiceScanner_ConcurrentScans runs scanners concurrently with a shared scan context, as the scan of a subscription does.
+// Run with -race to detect scanners writing the site configuration to the shared scan context.
+func TestAppServiceScanner_ConcurrentScans(t *testing.T) {
+	s, err := testserver.ParseSeed([]byte(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := testserver.New(s)
+	defer server.Close()
+
+	config := &models.ScannerConfig{
+		Ctx:  context.Background(),
+		Cred: server.Credential(),
+		ClientOptions: &arm.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Transport: server.Transport(),
+				Retry:     policy.RetryOptions{MaxRetries: -1},
+			},
+		},
+		SubscriptionID: "00000000-0000-0000-0000-000000000001",
+	}
+	scanContext := &models.ScanContext{
+		Filters:             models.NewFilters(),
+		DiagnosticsSettings: map[string]bool{},
+	}
+
+	const scans = 8
+	results := make([][]models.AzqrServiceResult, scans)
+	errs := make([]error, scans)
+	var wg sync.WaitGroup
+	for i := 0; i < scans; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			scanner := &AppServiceScanner{}
+			if errs[i] = scanner.Init(config); errs[i] != nil {
+				return
+			}
+			results[i], errs[i] = scanner.Scan(scanContext)
+		}(i)
+	}
+	wg.Wait()
+
+	want := map[string]bool{"tls12": false, "tls10": true}
+	for i := 0; i < scans; i++ {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		// the plan and its sites
+		if len(results[i]) != len(want)+1 {
+			t.Fatalf("scan %d returned %d resources, want %d", i, len(results[i]), len(want)+1)
+		}
+		for _, r := range results[i] {
+			if _, ok := want[r.ServiceName]; !ok {
+				continue
+			}
+			t.Run(fmt.Sprintf("%d/%s", i, r.ServiceName), func(t *testing.T) {
+				got := r.Recommendations["app-011"]
+				if got.Status == models.StatusError || got.NotCompliant != want[r.ServiceName] {
+					t.Errorf("%s app-011 = %v (%s), want %v", r.ServiceName, got.NotCompliant, got.Status, want[r.ServiceName])
+				}
+			})
+		}
+	}
+	if scanContext.SiteConfig != nil {
+		t.Error("scanners must not set the resource data of the shared scan context")
+	}
+}
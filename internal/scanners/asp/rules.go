@@ -38,6 +38,7 @@ func (a *AppServiceScanner) getPlanRules() map[string]models.AzqrRecommendation
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence: []string{"scanContext.DiagnosticsSettings"},
 		},
 		"asp-003": {
 			RecommendationID:   "asp-003",
@@ -55,6 +56,7 @@ func (a *AppServiceScanner) getPlanRules() map[string]models.AzqrRecommendation
 				}
 				return sla == "None", sla
 			},
+			Evidence:     []string{"SKU.Tier"},
 			LearnMoreUrl: "https://www.azure.cn/en-us/support/sla/app-service/",
 		},
 		"asp-006": {
@@ -65,9 +67,9 @@ func (a *AppServiceScanner) getPlanRules() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappservice.Plan)
-				caf := strings.HasPrefix(*c.Name, "asp")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Web/serverfarms", "asp", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"asp-007": {
@@ -78,8 +80,9 @@ func (a *AppServiceScanner) getPlanRules() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappservice.Plan)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -98,6 +101,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/troubleshoot-diagnostic-logs#send-logs-to-azure-monitor",
 		},
 		"app-004": {
@@ -111,6 +115,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				_, pe := scanContext.PrivateEndpoints[*i.ID]
 				return !pe, ""
 			},
+			Evidence:     []string{"scanContext.PrivateEndpoints"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/networking/private-endpoint",
 		},
 		"app-006": {
@@ -121,9 +126,9 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappservice.Site)
-				caf := strings.HasPrefix(*c.Name, "app")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Web/sites", "app", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"app-007": {
@@ -137,6 +142,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				h := *c.Properties.HTTPSOnly
 				return !h, ""
 			},
+			Evidence:     []string{"Properties.HTTPSOnly"},
 			LearnMoreUrl: "https://learn.microsoft.com/azure/app-service/configure-ssl-bindings#enforce-https",
 		},
 		"app-008": {
@@ -147,8 +153,9 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappservice.Site)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"app-009": {
@@ -161,6 +168,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				c := target.(*armappservice.Site)
 				return c.Properties.VirtualNetworkSubnetID == nil || len(*c.Properties.VirtualNetworkSubnetID) == 0, ""
 			},
+			Evidence:     []string{"Properties.VirtualNetworkSubnetID"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
 		},
 		"app-010": {
@@ -173,6 +181,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				c := target.(*armappservice.Site)
 				return c.Properties.VnetRouteAllEnabled == nil || !*c.Properties.VnetRouteAllEnabled, ""
 			},
+			Evidence:     []string{"Properties.VnetRouteAllEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
 		},
 		"app-011": {
@@ -185,6 +194,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				broken := scanContext.SiteConfig.Properties.MinTLSVersion == nil || *scanContext.SiteConfig.Properties.MinTLSVersion != armappservice.SupportedTLSVersionsOne2
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.SiteConfig.Properties.MinTLSVersion"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-tls",
 		},
 		"app-012": {
@@ -197,6 +207,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				broken := scanContext.SiteConfig.Properties.RemoteDebuggingEnabled == nil || *scanContext.SiteConfig.Properties.RemoteDebuggingEnabled
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.SiteConfig.Properties.RemoteDebuggingEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/visualstudio/debugger/remote-debugging-azure-app-service?view=vs-2022#enable-remote-debugging",
 		},
 		"app-013": {
@@ -209,6 +220,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				broken := scanContext.SiteConfig.Properties.FtpsState == nil || *scanContext.SiteConfig.Properties.FtpsState == armappservice.FtpsStateAllAllowed
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.SiteConfig.Properties.FtpsState"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/deploy-ftp?tabs=portal",
 		},
 		"app-014": {
@@ -221,6 +233,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				broken := scanContext.SiteConfig.Properties.AlwaysOn == nil || !*scanContext.SiteConfig.Properties.AlwaysOn
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.SiteConfig.Properties.AlwaysOn"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/configure-common?tabs=portal",
 		},
 		"app-015": {
@@ -233,6 +246,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				c := target.(*armappservice.Site)
 				return c.Properties.ClientAffinityEnabled != nil && *c.Properties.ClientAffinityEnabled, ""
 			},
+			Evidence:     []string{"Properties.ClientAffinityEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/azure-app-service/reliability#checklist",
 		},
 		"app-016": {
@@ -248,6 +262,7 @@ func (a *AppServiceScanner) getAppRules() map[string]models.AzqrRecommendation {
 				ok := scanContext.SiteConfig.Properties.ManagedServiceIdentityID != nil || scanContext.SiteConfig.Properties.XManagedServiceIdentityID != nil
 				return !ok, ""
 			},
+			Evidence:     []string{"Identity.Type", "scanContext.SiteConfig.Properties.ManagedServiceIdentityID", "scanContext.SiteConfig.Properties.XManagedServiceIdentityID"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-managed-identity?tabs=portal%2Chttp",
 		},
 	}
@@ -266,6 +281,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-functions/functions-monitor-log-analytics?tabs=csharp",
 		},
 		"func-004": {
@@ -279,6 +295,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				_, pe := scanContext.PrivateEndpoints[*i.ID]
 				return !pe, ""
 			},
+			Evidence:     []string{"scanContext.PrivateEndpoints"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-functions/functions-create-vnet",
 		},
 		"func-006": {
@@ -289,9 +306,9 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappservice.Site)
-				caf := strings.HasPrefix(*c.Name, "func")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Web/sites", "func", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"func-007": {
@@ -305,6 +322,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				h := c.Properties.HTTPSOnly != nil && *c.Properties.HTTPSOnly
 				return !h, ""
 			},
+			Evidence:     []string{"Properties.HTTPSOnly"},
 			LearnMoreUrl: "https://learn.microsoft.com/azure/app-service/configure-ssl-bindings#enforce-https",
 		},
 		"func-008": {
@@ -315,8 +333,9 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappservice.Site)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"func-009": {
@@ -329,6 +348,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				c := target.(*armappservice.Site)
 				return c.Properties.VirtualNetworkSubnetID == nil || len(*c.Properties.VirtualNetworkSubnetID) == 0, ""
 			},
+			Evidence:     []string{"Properties.VirtualNetworkSubnetID"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
 		},
 		"func-010": {
@@ -341,6 +361,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				c := target.(*armappservice.Site)
 				return c.Properties.VnetRouteAllEnabled == nil || !*c.Properties.VnetRouteAllEnabled, ""
 			},
+			Evidence:     []string{"Properties.VnetRouteAllEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
 		},
 		"func-011": {
@@ -353,6 +374,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				broken := scanContext.SiteConfig.Properties.MinTLSVersion == nil || *scanContext.SiteConfig.Properties.MinTLSVersion != armappservice.SupportedTLSVersionsOne2
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.SiteConfig.Properties.MinTLSVersion"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-tls",
 		},
 		"func-012": {
@@ -365,6 +387,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				broken := scanContext.SiteConfig.Properties.RemoteDebuggingEnabled == nil || *scanContext.SiteConfig.Properties.RemoteDebuggingEnabled
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.SiteConfig.Properties.RemoteDebuggingEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/visualstudio/debugger/remote-debugging-azure-app-service?view=vs-2022#enable-remote-debugging",
 		},
 		"func-013": {
@@ -377,6 +400,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				c := target.(*armappservice.Site)
 				return c.Properties.ClientAffinityEnabled != nil && *c.Properties.ClientAffinityEnabled, ""
 			},
+			Evidence:     []string{"Properties.ClientAffinityEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/azure-app-service/reliability#checklist",
 		},
 		"func-014": {
@@ -392,6 +416,7 @@ func (a *AppServiceScanner) getFunctionRules() map[string]models.AzqrRecommendat
 				ok := scanContext.SiteConfig.Properties.ManagedServiceIdentityID != nil || scanContext.SiteConfig.Properties.XManagedServiceIdentityID != nil
 				return !ok, ""
 			},
+			Evidence:     []string{"Identity.Type", "scanContext.SiteConfig.Properties.ManagedServiceIdentityID", "scanContext.SiteConfig.Properties.XManagedServiceIdentityID"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-managed-identity?tabs=portal%2Chttp",
 		},
 	}
@@ -410,6 +435,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/logic-apps/monitor-workflows-collect-diagnostic-data",
 		},
 		"logics-004": {
@@ -423,6 +449,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				_, pe := scanContext.PrivateEndpoints[*i.ID]
 				return !pe, ""
 			},
+			Evidence:     []string{"scanContext.PrivateEndpoints"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/logic-apps/secure-single-tenant-workflow-virtual-network-private-endpoint",
 		},
 		"logics-006": {
@@ -433,9 +460,9 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappservice.Site)
-				caf := strings.HasPrefix(*c.Name, "logic")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Web/sites", "logic", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"logics-007": {
@@ -449,6 +476,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				h := c.Properties.HTTPSOnly != nil && *c.Properties.HTTPSOnly
 				return !h, ""
 			},
+			Evidence:     []string{"Properties.HTTPSOnly"},
 			LearnMoreUrl: "https://learn.microsoft.com/azure/app-service/configure-ssl-bindings#enforce-https",
 		},
 		"logics-008": {
@@ -459,8 +487,9 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappservice.Site)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"logics-009": {
@@ -473,6 +502,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				c := target.(*armappservice.Site)
 				return c.Properties.VirtualNetworkSubnetID == nil || len(*c.Properties.VirtualNetworkSubnetID) == 0, ""
 			},
+			Evidence:     []string{"Properties.VirtualNetworkSubnetID"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
 		},
 		"logics-010": {
@@ -485,6 +515,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				c := target.(*armappservice.Site)
 				return c.Properties.VnetRouteAllEnabled == nil || !*c.Properties.VnetRouteAllEnabled, ""
 			},
+			Evidence:     []string{"Properties.VnetRouteAllEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
 		},
 		"logics-011": {
@@ -497,6 +528,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				broken := scanContext.SiteConfig.Properties.MinTLSVersion == nil || *scanContext.SiteConfig.Properties.MinTLSVersion != armappservice.SupportedTLSVersionsOne2
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.SiteConfig.Properties.MinTLSVersion"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-tls",
 		},
 		"logics-012": {
@@ -509,6 +541,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				broken := scanContext.SiteConfig.Properties.RemoteDebuggingEnabled == nil || *scanContext.SiteConfig.Properties.RemoteDebuggingEnabled
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.SiteConfig.Properties.RemoteDebuggingEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/visualstudio/debugger/remote-debugging-azure-app-service?view=vs-2022#enable-remote-debugging",
 		},
 		"logics-013": {
@@ -521,6 +554,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				c := target.(*armappservice.Site)
 				return c.Properties.ClientAffinityEnabled != nil && *c.Properties.ClientAffinityEnabled, ""
 			},
+			Evidence:     []string{"Properties.ClientAffinityEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/azure-app-service/reliability#checklist",
 		},
 		"logics-014": {
@@ -536,6 +570,7 @@ func (a *AppServiceScanner) getLogicRules() map[string]models.AzqrRecommendation
 				ok := scanContext.SiteConfig.Properties.ManagedServiceIdentityID != nil || scanContext.SiteConfig.Properties.XManagedServiceIdentityID != nil
 				return !ok, ""
 			},
+			Evidence:     []string{"Identity.Type", "scanContext.SiteConfig.Properties.ManagedServiceIdentityID", "scanContext.SiteConfig.Properties.XManagedServiceIdentityID"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/app-service/overview-managed-identity?tabs=portal%2Chttp",
 		},
 	}
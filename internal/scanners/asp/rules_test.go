@@ -274,7 +274,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 			fields: fields{
 				rule:   "app-011",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -282,7 +282,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: false,
@@ -294,7 +294,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 			fields: fields{
 				rule:   "app-012",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -302,7 +302,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: true,
@@ -314,7 +314,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 			fields: fields{
 				rule:   "app-013",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -322,7 +322,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: true,
@@ -334,7 +334,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 			fields: fields{
 				rule:   "app-014",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -342,7 +342,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: true,
@@ -370,7 +370,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 			fields: fields{
 				rule:   "app-016",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -378,7 +378,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: true,
@@ -390,7 +390,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 			fields: fields{
 				rule:   "app-016",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -398,7 +398,7 @@ func TestAppServiceScanner_AppRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: false,
@@ -588,7 +588,7 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 			fields: fields{
 				rule:   "func-011",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -596,7 +596,7 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: false,
@@ -608,7 +608,7 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 			fields: fields{
 				rule:   "func-012",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -616,7 +616,7 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: true,
@@ -644,7 +644,7 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 			fields: fields{
 				rule:   "func-014",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -652,7 +652,7 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: true,
@@ -664,7 +664,7 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 			fields: fields{
 				rule:   "func-014",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -672,7 +672,7 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: false,
@@ -862,7 +862,7 @@ func TestAppServiceScanner_LogicRules(t *testing.T) {
 			fields: fields{
 				rule:   "logics-011",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -870,7 +870,7 @@ func TestAppServiceScanner_LogicRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: false,
@@ -882,7 +882,7 @@ func TestAppServiceScanner_LogicRules(t *testing.T) {
 			fields: fields{
 				rule:   "logics-012",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -890,7 +890,7 @@ func TestAppServiceScanner_LogicRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: true,
@@ -918,7 +918,7 @@ func TestAppServiceScanner_LogicRules(t *testing.T) {
 			fields: fields{
 				rule:   "logics-014",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -926,7 +926,7 @@ func TestAppServiceScanner_LogicRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: true,
@@ -938,7 +938,7 @@ func TestAppServiceScanner_LogicRules(t *testing.T) {
 			fields: fields{
 				rule:   "logics-014",
 				target: &armappservice.Site{},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
 						SiteConfigResource: armappservice.SiteConfigResource{
 							Properties: &armappservice.SiteConfig{
@@ -946,7 +946,7 @@ func TestAppServiceScanner_LogicRules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: false,
@@ -4,8 +4,6 @@
 package ca
 
 import (
-	"strings"
-
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v2"
 )
@@ -33,9 +31,9 @@ func (a *ContainerAppsScanner) GetRecommendations() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappcontainers.ContainerApp)
-				caf := strings.HasPrefix(*c.Name, "ca")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.App/containerApps", "ca", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"ca-007": {
@@ -46,8 +44,9 @@ func (a *ContainerAppsScanner) GetRecommendations() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappcontainers.ContainerApp)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"ca-008": {
@@ -63,6 +62,7 @@ func (a *ContainerAppsScanner) GetRecommendations() map[string]models.AzqrRecomm
 				}
 				return false, ""
 			},
+			Evidence:     []string{"Properties.Configuration.Ingress.AllowInsecure"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-apps/ingress-how-to?pivots=azure-cli",
 		},
 		"ca-009": {
@@ -75,6 +75,7 @@ func (a *ContainerAppsScanner) GetRecommendations() map[string]models.AzqrRecomm
 				c := target.(*armappcontainers.ContainerApp)
 				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armappcontainers.ManagedServiceIdentityTypeNone, ""
 			},
+			Evidence:     []string{"Identity.Type"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-apps/managed-identity?tabs=portal%2Cdotnet",
 		},
 		"ca-010": {
@@ -96,6 +97,7 @@ func (a *ContainerAppsScanner) GetRecommendations() map[string]models.AzqrRecomm
 
 				return !ok, ""
 			},
+			Evidence:     []string{"Properties.Template.Volumes"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-apps/storage-mounts?pivots=azure-cli",
 		},
 		"ca-011": {
@@ -112,6 +114,7 @@ func (a *ContainerAppsScanner) GetRecommendations() map[string]models.AzqrRecomm
 					c.Properties.Configuration.Ingress.StickySessions.Affinity != nil &&
 					*c.Properties.Configuration.Ingress.StickySessions.Affinity == armappcontainers.AffinitySticky, ""
 			},
+			Evidence:     []string{"Properties.Configuration.Ingress.StickySessions.Affinity"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-apps/sticky-sessions?pivots=azure-portal",
 		},
 	}
@@ -24,6 +24,7 @@ func (a *ContainerAppsEnvironmentScanner) GetRecommendations() map[string]models
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-apps/log-options#diagnostic-settings",
 		},
 		"cae-003": {
@@ -49,6 +50,7 @@ func (a *ContainerAppsEnvironmentScanner) GetRecommendations() map[string]models
 				pe := app.Properties.VnetConfiguration != nil && *app.Properties.VnetConfiguration.Internal
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.VnetConfiguration.Internal"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-apps/vnet-custom-internal?tabs=bash&pivots=azure-portal",
 		},
 		"cae-006": {
@@ -59,9 +61,9 @@ func (a *ContainerAppsEnvironmentScanner) GetRecommendations() map[string]models
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappcontainers.ManagedEnvironment)
-				caf := strings.HasPrefix(*c.Name, "cae")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.App/managedenvironments", "cae", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"cae-007": {
@@ -72,8 +74,9 @@ func (a *ContainerAppsEnvironmentScanner) GetRecommendations() map[string]models
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armappcontainers.ManagedEnvironment)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
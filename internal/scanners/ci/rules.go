@@ -4,8 +4,6 @@
 package ci
 
 import (
-	"strings"
-
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerinstance/armcontainerinstance"
 )
@@ -24,6 +22,11 @@ func (a *ContainerInstanceScanner) GetRecommendations() map[string]models.AzqrRe
 				zones := len(i.Zones) > 0
 				return !zones, ""
 			},
+			Evidence: []string{"Zones"},
+			Applies: func(target interface{}, scanContext *models.ScanContext) bool {
+				i := target.(*armcontainerinstance.ContainerGroup)
+				return models.HasAvailabilityZones(i.Location)
+			},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-instances/availability-zones",
 		},
 		"ci-003": {
@@ -52,6 +55,7 @@ func (a *ContainerInstanceScanner) GetRecommendations() map[string]models.AzqrRe
 				}
 				return !pe, ""
 			},
+			Evidence: []string{"Properties.IPAddress.Type"},
 		},
 		"ci-006": {
 			RecommendationID: "ci-006",
@@ -61,9 +65,9 @@ func (a *ContainerInstanceScanner) GetRecommendations() map[string]models.AzqrRe
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcontainerinstance.ContainerGroup)
-				caf := strings.HasPrefix(*c.Name, "ci")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.ContainerInstance/containerGroups", "ci", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"ci-007": {
@@ -74,8 +78,9 @@ func (a *ContainerInstanceScanner) GetRecommendations() map[string]models.AzqrRe
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcontainerinstance.ContainerGroup)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -24,6 +24,7 @@ func (a *CognitiveScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/event-hubs/monitor-event-hubs#collection-and-routing",
 		},
 		"cog-003": {
@@ -49,6 +50,7 @@ func (a *CognitiveScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cognitive-services/cognitive-services-virtual-networks",
 		},
 		"cog-006": {
@@ -61,35 +63,36 @@ func (a *CognitiveScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				c := target.(*armcognitiveservices.Account)
 				switch strings.ToLower(*c.Kind) {
 				case "openai":
-					return !strings.HasPrefix(*c.Name, "oai"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "oai", *c.Name)
 				case "computervision":
-					return !strings.HasPrefix(*c.Name, "cv"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "cv", *c.Name)
 				case "contentmoderator":
-					return !strings.HasPrefix(*c.Name, "cm"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "cm", *c.Name)
 				case "contentsafety":
-					return !strings.HasPrefix(*c.Name, "cs"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "cs", *c.Name)
 				case "customvision.prediction":
-					return !strings.HasPrefix(*c.Name, "cstv"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "cstv", *c.Name)
 				case "customvision.training":
-					return !strings.HasPrefix(*c.Name, "cstvt"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "cstvt", *c.Name)
 				case "formrecognizer":
-					return !strings.HasPrefix(*c.Name, "di"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "di", *c.Name)
 				case "face":
-					return !strings.HasPrefix(*c.Name, "face"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "face", *c.Name)
 				case "healthinsights":
-					return !strings.HasPrefix(*c.Name, "hi"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "hi", *c.Name)
 				case "immersivereader":
-					return !strings.HasPrefix(*c.Name, "ir"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "ir", *c.Name)
 				case "textanalytics":
-					return !strings.HasPrefix(*c.Name, "lang"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "lang", *c.Name)
 				case "speechservices":
-					return !strings.HasPrefix(*c.Name, "spch"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "spch", *c.Name)
 				case "texttranslation":
-					return !strings.HasPrefix(*c.Name, "trsl"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "trsl", *c.Name)
 				default:
-					return !strings.HasPrefix(*c.Name, "cog"), ""
+					return scanContext.CheckNaming("Microsoft.CognitiveServices/accounts", "cog", *c.Name)
 				}
 			},
+			Evidence:     []string{"Kind", "Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"cog-007": {
@@ -100,8 +103,9 @@ func (a *CognitiveScanner) GetRecommendations() map[string]models.AzqrRecommenda
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcognitiveservices.Account)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"cog-008": {
@@ -115,6 +119,7 @@ func (a *CognitiveScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
 				return !localAuth, ""
 			},
+			Evidence:     []string{"Properties.DisableLocalAuth"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/ai-services/policy-reference#azure-ai-services",
 		},
 	}
@@ -24,6 +24,7 @@ func (a *CosmosDBScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cosmos-db/monitor-resource-logs",
 		},
 		"cosmos-003": {
@@ -54,6 +55,7 @@ func (a *CosmosDBScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				}
 				return false, sla
 			},
+			Evidence:     []string{"Properties.Locations"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cosmos-db/high-availability#slas",
 		},
 		"cosmos-004": {
@@ -67,6 +69,7 @@ func (a *CosmosDBScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cosmos-db/how-to-configure-private-endpoints",
 		},
 		"cosmos-006": {
@@ -77,9 +80,9 @@ func (a *CosmosDBScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcosmos.DatabaseAccountGetResults)
-				caf := strings.HasPrefix(*c.Name, "cosmos")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.DocumentDB/databaseAccounts", "cosmos", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"cosmos-007": {
@@ -90,8 +93,9 @@ func (a *CosmosDBScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcosmos.DatabaseAccountGetResults)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"cosmos-008": {
@@ -105,6 +109,7 @@ func (a *CosmosDBScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
 				return !localAuth, ""
 			},
+			Evidence:     []string{"Properties.DisableLocalAuth"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cosmos-db/how-to-setup-rbac#disable-local-auth",
 		},
 		"cosmos-009": {
@@ -118,6 +123,7 @@ func (a *CosmosDBScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				disabled := c.Properties.DisableKeyBasedMetadataWriteAccess != nil && *c.Properties.DisableKeyBasedMetadataWriteAccess
 				return !disabled, ""
 			},
+			Evidence:     []string{"Properties.DisableKeyBasedMetadataWriteAccess"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cosmos-db/role-based-access-control#set-via-arm-template",
 		},
 	}
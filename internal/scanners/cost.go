@@ -9,7 +9,6 @@ import (
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement"
-	"github.com/rs/zerolog/log"
 )
 
 // CostScanner - Cost scanner
@@ -85,22 +84,25 @@ func (s *CostScanner) QueryCosts() (*models.CostResult, error) {
 	return &result, nil
 }
 
-func (s *CostScanner) Scan(scan bool, config *models.ScannerConfig) *models.CostResult {
+func (s *CostScanner) Scan(scan bool, config *models.ScannerConfig) (*models.CostResult, error) {
 	costResult := &models.CostResult{
 		Items: []*models.CostResultItem{},
 	}
 	if scan {
 		err := s.Init(config)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to initialize Cost Scanner")
+			return nil, fmt.Errorf("failed to initialize Cost Scanner: %w", err)
 		}
 		costs, err := s.QueryCosts()
-		if err != nil && !models.ShouldSkipError(err) {
-			log.Fatal().Err(err).Msg("Failed to query costs")
+		if err != nil {
+			if models.ShouldSkipError(err) {
+				return costResult, nil
+			}
+			return nil, fmt.Errorf("failed to query costs: %w", err)
 		}
 		costResult.From = costs.From
 		costResult.To = costs.To
 		costResult.Items = append(costResult.Items, costs.Items...)
 	}
-	return costResult
+	return costResult, nil
 }
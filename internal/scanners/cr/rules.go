@@ -24,6 +24,7 @@ func (a *ContainerRegistryScanner) GetRecommendations() map[string]models.AzqrRe
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-registry/monitor-service",
 		},
 		"cr-003": {
@@ -49,6 +50,7 @@ func (a *ContainerRegistryScanner) GetRecommendations() map[string]models.AzqrRe
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-registry/container-registry-private-link",
 		},
 		"cr-006": {
@@ -59,9 +61,9 @@ func (a *ContainerRegistryScanner) GetRecommendations() map[string]models.AzqrRe
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcontainerregistry.Registry)
-				caf := strings.HasPrefix(*c.Name, "cr")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.ContainerRegistry/registries", "cr", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"cr-008": {
@@ -75,6 +77,7 @@ func (a *ContainerRegistryScanner) GetRecommendations() map[string]models.AzqrRe
 				admin := c.Properties.AdminUserEnabled != nil && *c.Properties.AdminUserEnabled
 				return admin, ""
 			},
+			Evidence:     []string{"Properties.AdminUserEnabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/azure/container-registry/container-registry-authentication-managed-identity",
 		},
 		"cr-009": {
@@ -85,8 +88,9 @@ func (a *ContainerRegistryScanner) GetRecommendations() map[string]models.AzqrRe
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcontainerregistry.Registry)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"cr-010": {
@@ -102,6 +106,7 @@ func (a *ContainerRegistryScanner) GetRecommendations() map[string]models.AzqrRe
 					c.Properties.Policies.RetentionPolicy.Status == nil ||
 					*c.Properties.Policies.RetentionPolicy.Status == armcontainerregistry.PolicyStatusDisabled, ""
 			},
+			Evidence:     []string{"Properties.Policies.RetentionPolicy.Status"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/container-registry/container-registry-retention-policy",
 		},
 	}
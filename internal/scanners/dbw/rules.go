@@ -24,6 +24,7 @@ func (a *DatabricksScanner) GetRecommendations() map[string]models.AzqrRecommend
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/databricks/administration-guide/account-settings/audit-log-delivery",
 		},
 		"dbw-003": {
@@ -49,6 +50,7 @@ func (a *DatabricksScanner) GetRecommendations() map[string]models.AzqrRecommend
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/databricks/administration-guide/cloud-configurations/azure/private-link",
 		},
 		"dbw-006": {
@@ -59,9 +61,9 @@ func (a *DatabricksScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armdatabricks.Workspace)
-				caf := strings.HasPrefix(*c.Name, "dbw")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Databricks/workspaces", "dbw", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"dbw-007": {
@@ -80,6 +82,7 @@ func (a *DatabricksScanner) GetRecommendations() map[string]models.AzqrRecommend
 
 				return !ok, ""
 			},
+			Evidence:     []string{"Properties.Parameters.EnableNoPublicIP.Value"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/databricks/security/network/secure-cluster-connectivity",
 		},
 	}
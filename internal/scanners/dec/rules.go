@@ -24,6 +24,7 @@ func (a *DataExplorerScanner) GetRecommendations() map[string]models.AzqrRecomme
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/data-explorer/using-diagnostic-logs",
 		},
 		"dec-002": {
@@ -42,6 +43,7 @@ func (a *DataExplorerScanner) GetRecommendations() map[string]models.AzqrRecomme
 
 				return sla == "None", sla
 			},
+			Evidence:     []string{"SKU.Name"},
 			LearnMoreUrl: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
 		"dec-003": {
@@ -59,6 +61,7 @@ func (a *DataExplorerScanner) GetRecommendations() map[string]models.AzqrRecomme
 				}
 				return broken, string(*c.SKU.Name)
 			},
+			Evidence:     []string{"SKU.Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/data-explorer/manage-cluster-choose-sku",
 		},
 		"dec-004": {
@@ -72,6 +75,7 @@ func (a *DataExplorerScanner) GetRecommendations() map[string]models.AzqrRecomme
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/data-explorer/security-network-private-endpoint",
 		},
 		"dec-006": {
@@ -82,9 +86,9 @@ func (a *DataExplorerScanner) GetRecommendations() map[string]models.AzqrRecomme
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armkusto.Cluster)
-				caf := strings.HasPrefix(*c.Name, "dec")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Kusto/clusters", "dec", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"dec-007": {
@@ -95,8 +99,9 @@ func (a *DataExplorerScanner) GetRecommendations() map[string]models.AzqrRecomme
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armkusto.Cluster)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"dec-008": {
@@ -109,6 +114,7 @@ func (a *DataExplorerScanner) GetRecommendations() map[string]models.AzqrRecomme
 				c := target.(*armkusto.Cluster)
 				return c.Properties.EnableDiskEncryption == nil || !*c.Properties.EnableDiskEncryption, ""
 			},
+			Evidence:     []string{"Properties.EnableDiskEncryption"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/data-explorer/cluster-encryption-overview",
 		},
 		"dec-009": {
@@ -121,6 +127,7 @@ func (a *DataExplorerScanner) GetRecommendations() map[string]models.AzqrRecomme
 				c := target.(*armkusto.Cluster)
 				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armkusto.IdentityTypeNone, ""
 			},
+			Evidence:     []string{"Identity.Type"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/data-explorer/configure-managed-identities-cluster?tabs=portal",
 		},
 	}
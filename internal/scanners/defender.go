@@ -11,18 +11,22 @@ import (
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/rs/zerolog/log"
 )
 
 // DefenderScanner - Defender scanner
 type DefenderScanner struct{}
 
-func (s *DefenderScanner) Scan(ctx context.Context, scan bool, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters) []models.DefenderResult {
+func (s *DefenderScanner) Scan(ctx context.Context, scan bool, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters, options *arm.ClientOptions) ([]models.DefenderResult, error) {
 	models.LogResourceTypeScan("Defender Status")
 	resources := []models.DefenderResult{}
 
 	if scan {
-		graphClient := graph.NewGraphQuery(cred)
+		graphClient, err := graph.NewGraphQuery(cred, options)
+		if err != nil {
+			return nil, err
+		}
 		query := `
 		SecurityResources
 		| join kind=inner (
@@ -38,7 +42,10 @@ func (s *DefenderScanner) Scan(ctx context.Context, scan bool, cred azcore.Token
 		for s := range subscriptions {
 			subs = append(subs, &s)
 		}
-		result := graphClient.Query(ctx, query, subs)
+		result, err := graphClient.Query(ctx, query, subs)
+		if err != nil {
+			return nil, err
+		}
 		resources = []models.DefenderResult{}
 		if result.Data != nil {
 			for _, row := range result.Data {
@@ -57,15 +64,18 @@ func (s *DefenderScanner) Scan(ctx context.Context, scan bool, cred azcore.Token
 			}
 		}
 	}
-	return resources
+	return resources, nil
 }
 
-func (s *DefenderScanner) GetRecommendations(ctx context.Context, scan bool, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters) []models.DefenderRecommendation {
+func (s *DefenderScanner) GetRecommendations(ctx context.Context, scan bool, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters, options *arm.ClientOptions) ([]models.DefenderRecommendation, error) {
 	models.LogResourceTypeScan("Defender Recommendations")
 	resources := []models.DefenderRecommendation{}
 
 	if scan {
-		graphClient := graph.NewGraphQuery(cred)
+		graphClient, err := graph.NewGraphQuery(cred, options)
+		if err != nil {
+			return nil, err
+		}
 		query := `
 		SecurityResources
 		| where type == 'microsoft.security/assessments'
@@ -103,7 +113,10 @@ func (s *DefenderScanner) GetRecommendations(ctx context.Context, scan bool, cre
 		for s := range subscriptions {
 			subs = append(subs, &s)
 		}
-		result := graphClient.Query(ctx, query, subs)
+		result, err := graphClient.Query(ctx, query, subs)
+		if err != nil {
+			return nil, err
+		}
 		resources = []models.DefenderRecommendation{}
 		if result.Data != nil {
 			for _, row := range result.Data {
@@ -130,5 +143,5 @@ func (s *DefenderScanner) GetRecommendations(ctx context.Context, scan bool, cre
 			}
 		}
 	}
-	return resources
+	return resources, nil
 }
@@ -6,6 +6,7 @@ package scanners
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -14,20 +15,21 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Azure/azqr/internal/az"
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azqr/internal/throttling"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
 	"github.com/rs/zerolog/log"
 )
 
 // DiagnosticSettingsScanner - scanner for diagnostic settings
 type DiagnosticSettingsScanner struct {
-	ctx         context.Context
-	httpClient  *http.Client
-	accessToken string
+	ctx      context.Context
+	pipeline runtime.Pipeline
+	endpoint string
 }
 
 const (
@@ -37,23 +39,14 @@ const (
 
 // Init - Initializes the DiagnosticSettingsScanner
 func (d *DiagnosticSettingsScanner) Init(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions) error {
-	// Create a new HTTP client with a timeout
-	httpClient := &http.Client{
-		Timeout: 60 * time.Second,
-	}
-	d.httpClient = httpClient
-	d.ctx = ctx
-
-	// Acquire an access token using the provided credential
-	token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{
-		Scopes: []string{"https://management.azure.com/.default"},
-	})
+	client, err := az.NewClient(cred, options)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to acquire Azure access token")
+		return fmt.Errorf("failed to create ARM batch client: %w", err)
 	}
 
-	// Set the access token string
-	d.accessToken = token.Token
+	d.ctx = ctx
+	d.pipeline = client.Pipeline()
+	d.endpoint = client.Endpoint()
 	return nil
 }
 
@@ -82,12 +75,12 @@ func (d *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings(resource
 
 	log.Debug().Msgf("Number of diagnostic setting batches: %d", batches)
 	jobs := make(chan []*string, batches)
-	ch := make(chan map[string]bool, batches)
+	ch := make(chan diagnosticSettingsJobResult, batches)
 	var wg sync.WaitGroup
 
 	// Create a burst limiter to control the rate of requests
 	limiter := throttling.NewLimiter(bucketCapacity, refillRate, 1*time.Second, 0*time.Millisecond)
-	burstLimiter := limiter.Start()
+	burstLimiter := limiter.Start(d.ctx)
 
 	numWorkers := bucketCapacity
 	for w := 0; w < numWorkers; w++ {
@@ -109,79 +102,79 @@ func (d *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings(resource
 	close(jobs)
 	wg.Wait()
 
+	var errs []error
 	for i := 0; i < batches; i++ {
-		for k, v := range <-ch {
+		r := <-ch
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		for k, v := range r.results {
 			res[k] = v
 		}
 	}
 
-	return res, nil
+	return res, errors.Join(errs...)
+}
+
+// diagnosticSettingsJobResult holds the outcome of a single diagnostic settings batch.
+type diagnosticSettingsJobResult struct {
+	results map[string]bool
+	err     error
 }
 
-func (d *DiagnosticSettingsScanner) worker(jobs <-chan []*string, results chan<- map[string]bool, wg *sync.WaitGroup, burstLimiter <-chan struct{}) {
+func (d *DiagnosticSettingsScanner) worker(jobs <-chan []*string, results chan<- diagnosticSettingsJobResult, wg *sync.WaitGroup, burstLimiter <-chan struct{}) {
 	// Wait for a token from the burstLimiter channel before starting the scan
 	for ids := range jobs {
-		<-burstLimiter
-		resp, err := d.retry(d.ctx, 3, 10*time.Millisecond, ids)
-		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to get diagnostic settings")
+		select {
+		case <-burstLimiter:
+		case <-d.ctx.Done():
 		}
-		asyncRes := map[string]bool{}
-		for _, response := range resp.Responses {
-			if response.HttpStatusCode == http.StatusOK {
-				// Decode the response content into a DiagnosticSettingsResourceCollection
-				var diagnosticSettings armmonitor.DiagnosticSettingsResourceCollection
-				contentBytes, err := json.Marshal(response.Content)
-				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to marshal diagnostic settings content")
-					continue
-				}
-				// Unmarshal the JSON bytes into the DiagnosticSettingsResourceCollection struct
-				if err := json.Unmarshal(contentBytes, &diagnosticSettings); err != nil {
-					log.Fatal().Err(err).Msg("Failed to unmarshal diagnostic settings response")
-					continue
-				}
-
-				for _, diagnosticSetting := range diagnosticSettings.Value {
-					id := parseResourceId(diagnosticSetting.ID)
-					asyncRes[id] = true
-				}
-			}
+		if d.ctx.Err() != nil {
+			results <- diagnosticSettingsJobResult{err: d.ctx.Err()}
+			wg.Done()
+			continue
 		}
-		results <- asyncRes
+		asyncRes, err := d.scanBatch(ids)
+		results <- diagnosticSettingsJobResult{results: asyncRes, err: err}
 		wg.Done()
 	}
 }
 
-// retry executes the Resource Graph query with retries and exponential backoff.
-// Returns the QueryResponse or error.
-func (d *DiagnosticSettingsScanner) retry(ctx context.Context, attempts int, sleep time.Duration, resourceIds []*string) (*ArmBatchResponse, error) {
-	var err error
-	for i := 0; ; i++ {
-		resp, err := d.doRequest(ctx, resourceIds)
-		if err == nil {
-			return resp, nil
-		}
-
-		errAsString := err.Error()
+// scanBatch gets the diagnostic settings of a batch of resources
+func (d *DiagnosticSettingsScanner) scanBatch(ids []*string) (map[string]bool, error) {
+	resp, err := d.doRequest(d.ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diagnostic settings: %w", err)
+	}
+	asyncRes := map[string]bool{}
+	for _, response := range resp.Responses {
+		if response.HttpStatusCode == http.StatusOK {
+			// Decode the response content into a DiagnosticSettingsResourceCollection
+			var diagnosticSettings armmonitor.DiagnosticSettingsResourceCollection
+			contentBytes, err := json.Marshal(response.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal diagnostic settings content: %w", err)
+			}
+			// Unmarshal the JSON bytes into the DiagnosticSettingsResourceCollection struct
+			if err := json.Unmarshal(contentBytes, &diagnosticSettings); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal diagnostic settings response: %w", err)
+			}
 
-		if i >= (attempts - 1) {
-			log.Info().Msgf("Retry limit reached. Error: %s", errAsString)
-			break
+			for _, diagnosticSetting := range diagnosticSettings.Value {
+				id := parseResourceId(diagnosticSetting.ID)
+				asyncRes[id] = true
+			}
 		}
-
-		log.Debug().Msgf("Retrying after error: %s", errAsString)
-
-		time.Sleep(sleep)
-		sleep *= 2
 	}
-	return nil, err
+	return asyncRes, nil
 }
 
-// restCall performs a batch request to retrieve diagnostic settings using an HTTP client.
+// doRequest performs a batch request to retrieve diagnostic settings.
+// Transient failures and throttled requests are retried by the pipeline.
 func (d *DiagnosticSettingsScanner) doRequest(ctx context.Context, resourceIds []*string) (*ArmBatchResponse, error) {
 	// Build the batch endpoint URL.
-	batchURL := "https://management.azure.com/batch?api-version=2020-06-01"
+	batchURL := runtime.JoinPaths(d.endpoint, "/batch") + "?api-version=2020-06-01"
 
 	// Prepare the batch request payload.
 	batch := ArmBatchRequest{
@@ -194,31 +187,21 @@ func (d *DiagnosticSettingsScanner) doRequest(ctx context.Context, resourceIds [
 		})
 	}
 
-	// Marshal the batch request to JSON.
-	body, err := json.Marshal(batch)
+	// Create the HTTP request.
+	req, err := runtime.NewRequest(ctx, http.MethodPost, batchURL)
 	if err != nil {
 		return nil, err
 	}
-
-	// Create the HTTP request.
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, batchURL, strings.NewReader(string(body)))
-	if err != nil {
+	req.Raw().Header.Set("Accept", "application/json")
+	if err := runtime.MarshalAsJSON(req, batch); err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer "+d.accessToken)
 
 	// Send the HTTP request.
-	resp, err := d.httpClient.Do(req)
+	resp, err := d.pipeline.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Fatal().Err(err).Msg("Failed to close response body")
-		}
-	}()
 
 	quotaStr := resp.Header.Get("x-ms-ratelimit-remaining-tenant-reads")
 	if quotaStr != "" {
@@ -226,31 +209,17 @@ func (d *DiagnosticSettingsScanner) doRequest(ctx context.Context, resourceIds [
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse quota header: %w", err)
 		}
-		log.Debug().Msgf("Graph query remaining quota: %d", quota)
-		// Quota limit reached, sleep for the duration specified in the response header
-		if quota == 0 {
-			log.Debug().Msg("Graph query quota limit reached.")
-		}
+		log.Debug().Msgf("ARM batch remaining quota: %d", quota)
 	}
 
 	// Check for successful status code.
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		// Check if the response status code is 429 (Too Many Requests).
-		if resp.StatusCode == http.StatusTooManyRequests {
-			// Parse the Retry-After header from the response headers
-			retryAfterStr := resp.Header.Get("Retry-After")
-			retryAfter, _ := strconv.Atoi(retryAfterStr)
-			log.Debug().Msgf("Received 429 Too Many Requests. Retry-After: %d seconds", retryAfter)
-			time.Sleep(time.Duration(retryAfter) * time.Second)
-		}
-
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusAccepted) {
+		return nil, runtime.NewResponseError(resp)
 	}
 
 	// Decode the response body.
 	var result ArmBatchResponse
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&result); err != nil {
+	if err := runtime.UnmarshalAsJSON(resp, &result); err != nil {
 		return nil, err
 	}
 
@@ -283,14 +252,13 @@ type (
 	}
 )
 
-func (d *DiagnosticSettingsScanner) Scan(resources []*string) map[string]bool {
+func (d *DiagnosticSettingsScanner) Scan(resources []*string) (map[string]bool, error) {
 	diagResults, err := d.ListResourcesWithDiagnosticSettings(resources)
 	if err != nil {
 		if models.ShouldSkipError(err) {
-			diagResults = map[string]bool{}
-		} else {
-			log.Fatal().Err(err).Msg("Failed to list resources with Diagnostic Settings")
+			return map[string]bool{}, nil
 		}
+		return diagResults, fmt.Errorf("failed to list resources with Diagnostic Settings: %w", err)
 	}
-	return diagResults
+	return diagResults, nil
 }
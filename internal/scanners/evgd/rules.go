@@ -24,6 +24,7 @@ func (a *EventGridScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/event-grid/diagnostic-logs",
 		},
 		"evgd-003": {
@@ -49,6 +50,7 @@ func (a *EventGridScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/event-grid/configure-private-endpoints",
 		},
 		"evgd-006": {
@@ -59,9 +61,9 @@ func (a *EventGridScanner) GetRecommendations() map[string]models.AzqrRecommenda
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armeventgrid.Domain)
-				caf := strings.HasPrefix(*c.Name, "evgd")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.EventGrid/domains", "evgd", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"evgd-007": {
@@ -72,8 +74,9 @@ func (a *EventGridScanner) GetRecommendations() map[string]models.AzqrRecommenda
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armeventgrid.Domain)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"evgd-008": {
@@ -87,6 +90,7 @@ func (a *EventGridScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
 				return !localAuth, ""
 			},
+			Evidence:     []string{"Properties.DisableLocalAuth"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/event-grid/authenticate-with-access-keys-shared-access-signatures",
 		},
 	}
@@ -24,6 +24,7 @@ func (a *EventHubScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/event-hubs/monitor-event-hubs#collection-and-routing",
 		},
 		"evh-003": {
@@ -42,6 +43,7 @@ func (a *EventHubScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				}
 				return false, sla
 			},
+			Evidence:     []string{"SKU.Name"},
 			LearnMoreUrl: "https://www.azure.cn/en-us/support/sla/event-hubs/",
 		},
 		"evh-004": {
@@ -55,6 +57,7 @@ func (a *EventHubScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/event-hubs/network-security",
 		},
 		"evh-006": {
@@ -65,9 +68,9 @@ func (a *EventHubScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armeventhub.EHNamespace)
-				caf := strings.HasPrefix(*c.Name, "evh")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.EventHub/namespaces", "evh", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"evh-007": {
@@ -78,8 +81,9 @@ func (a *EventHubScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armeventhub.EHNamespace)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"evh-008": {
@@ -93,6 +97,7 @@ func (a *EventHubScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
 				return !localAuth, ""
 			},
+			Evidence:     []string{"Properties.DisableLocalAuth"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/event-hubs/authorize-access-event-hubs#shared-access-signatures",
 		},
 	}
@@ -4,8 +4,6 @@
 package it
 
 import (
-	"strings"
-
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/virtualmachineimagebuilder/armvirtualmachineimagebuilder/v2"
 )
@@ -21,9 +19,9 @@ func (a *ImageTemplateScanner) GetRecommendations() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armvirtualmachineimagebuilder.ImageTemplate)
-				caf := strings.HasPrefix(*c.Name, "it")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.VirtualMachineImages/imageTemplates", "it", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"it-007": {
@@ -34,8 +32,9 @@ func (a *ImageTemplateScanner) GetRecommendations() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armvirtualmachineimagebuilder.ImageTemplate)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
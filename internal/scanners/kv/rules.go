@@ -24,6 +24,7 @@ func (a *KeyVaultScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/key-vault/general/monitor-key-vault",
 		},
 		"kv-003": {
@@ -46,9 +47,9 @@ func (a *KeyVaultScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armkeyvault.Vault)
-				caf := strings.HasPrefix(*c.Name, "kv")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.KeyVault/vaults", "kv", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"kv-007": {
@@ -59,8 +60,9 @@ func (a *KeyVaultScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armkeyvault.Vault)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
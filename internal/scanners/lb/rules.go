@@ -24,6 +24,7 @@ func (a *LoadBalancerScanner) GetRecommendations() map[string]models.AzqrRecomme
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/load-balancer/monitor-load-balancer#creating-a-diagnostic-setting",
 		},
 		"lb-003": {
@@ -42,6 +43,7 @@ func (a *LoadBalancerScanner) GetRecommendations() map[string]models.AzqrRecomme
 				}
 				return sla == "None", sla
 			},
+			Evidence:     []string{"SKU.Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/load-balancer/skus",
 		},
 		"lb-006": {
@@ -68,9 +70,16 @@ func (a *LoadBalancerScanner) GetRecommendations() map[string]models.AzqrRecomme
 					}
 				}
 
-				caf := (strings.HasPrefix(*c.Name, "lbi") && hasPrivateIP) || (strings.HasPrefix(*c.Name, "lbe") && hasPublicIP)
-				return !caf, ""
+				broken, result := true, ""
+				if hasPrivateIP {
+					broken, result = scanContext.CheckNaming("Microsoft.Network/loadBalancers", "lbi", *c.Name)
+				}
+				if broken && hasPublicIP {
+					broken, result = scanContext.CheckNaming("Microsoft.Network/loadBalancers", "lbe", *c.Name)
+				}
+				return broken, result
 			},
+			Evidence:     []string{"Properties.FrontendIPConfigurations", "Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"lb-007": {
@@ -81,8 +90,9 @@ func (a *LoadBalancerScanner) GetRecommendations() map[string]models.AzqrRecomme
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.LoadBalancer)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
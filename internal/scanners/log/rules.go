@@ -4,8 +4,6 @@
 package log
 
 import (
-	"strings"
-
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationalinsights/armoperationalinsights/v2"
 )
@@ -33,9 +31,9 @@ func (a *LogAnalyticsScanner) GetRecommendations() map[string]models.AzqrRecomme
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armoperationalinsights.Workspace)
-				caf := strings.HasPrefix(*c.Name, "log")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.OperationalInsights/workspaces", "log", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"log-007": {
@@ -46,8 +44,9 @@ func (a *LogAnalyticsScanner) GetRecommendations() map[string]models.AzqrRecomme
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armoperationalinsights.Workspace)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -24,6 +24,7 @@ func (a *LogicAppScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/logic-apps/monitor-workflows-collect-diagnostic-data",
 		},
 		"logic-003": {
@@ -67,6 +68,7 @@ func (a *LogicAppScanner) GetRecommendations() map[string]models.AzqrRecommendat
 				}
 				return broken, ""
 			},
+			Evidence:     []string{"Properties.Definition", "Properties.AccessControl.Triggers.AllowedCallerIPAddresses"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/logic-apps/logic-apps-securing-a-logic-app?tabs=azure-portal#restrict-access-by-ip-address-range",
 		},
 		"logic-006": {
@@ -78,9 +80,9 @@ func (a *LogicAppScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armlogic.Workflow)
 
-				caf := strings.HasPrefix(*c.Name, "logic")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Logic/workflows", "logic", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"logic-007": {
@@ -91,8 +93,9 @@ func (a *LogicAppScanner) GetRecommendations() map[string]models.AzqrRecommendat
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armlogic.Workflow)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
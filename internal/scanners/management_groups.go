@@ -2,6 +2,7 @@ package scanners
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azqr/internal/to"
@@ -14,10 +15,10 @@ import (
 
 type ManagementGroupsScanner struct{}
 
-func (sc ManagementGroupsScanner) ListSubscriptions(ctx context.Context, cred azcore.TokenCredential, groups []string, filters *models.Filters, options *arm.ClientOptions) map[string]string {
+func (sc ManagementGroupsScanner) ListSubscriptions(ctx context.Context, cred azcore.TokenCredential, groups []string, filters *models.Filters, options *arm.ClientOptions) (map[string]string, error) {
 	client, err := armmanagementgroups.NewClientFactory(cred, options)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create management groups client")
+		return nil, fmt.Errorf("failed to create management groups client: %w", err)
 	}
 	result := map[string]string{}
 
@@ -28,7 +29,7 @@ func (sc ManagementGroupsScanner) ListSubscriptions(ctx context.Context, cred az
 		for resultPager.More() {
 			pageResp, err := resultPager.NextPage(ctx)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to list management group subscriptions")
+				return nil, fmt.Errorf("failed to list subscriptions of management group %s: %w", group, err)
 			}
 
 			for _, s := range pageResp.Value {
@@ -49,5 +50,5 @@ func (sc ManagementGroupsScanner) ListSubscriptions(ctx context.Context, cred az
 		}
 	}
 
-	return result
+	return result, nil
 }
@@ -24,6 +24,7 @@ func (a *MariaScanner) GetRecommendations() map[string]models.AzqrRecommendation
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence: []string{"scanContext.DiagnosticsSettings"},
 		},
 		"maria-002": {
 			RecommendationID: "maria-002",
@@ -36,6 +37,7 @@ func (a *MariaScanner) GetRecommendations() map[string]models.AzqrRecommendation
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence: []string{"Properties.PrivateEndpointConnections"},
 		},
 		"maria-003": {
 			RecommendationID: "maria-003",
@@ -45,9 +47,9 @@ func (a *MariaScanner) GetRecommendations() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armmariadb.Server)
-				caf := strings.HasPrefix(*c.Name, "maria")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.DBforMariaDB/servers", "maria", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"maria-004": {
@@ -69,8 +71,9 @@ func (a *MariaScanner) GetRecommendations() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armmariadb.Server)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"maria-006": {
@@ -83,6 +86,7 @@ func (a *MariaScanner) GetRecommendations() map[string]models.AzqrRecommendation
 				c := target.(*armmariadb.Server)
 				return c.Properties.MinimalTLSVersion == nil || *c.Properties.MinimalTLSVersion != armmariadb.MinimalTLSVersionEnumTLS12, ""
 			},
+			Evidence:     []string{"Properties.MinimalTLSVersion"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/mariadb/howto-tls-configurations",
 		},
 	}
@@ -99,9 +103,9 @@ func (a *MariaScanner) GetDatabaseRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armmariadb.Database)
-				caf := strings.HasPrefix(*c.Name, "mariadb")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.DBforMariaDB/servers/databases", "mariadb", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 	}
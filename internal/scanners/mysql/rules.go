@@ -25,6 +25,7 @@ func (a *MySQLScanner) GetRecommendations() map[string]models.AzqrRecommendation
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/mysql/single-server/concepts-monitoring#server-logs",
 		},
 		"mysql-003": {
@@ -50,6 +51,7 @@ func (a *MySQLScanner) GetRecommendations() map[string]models.AzqrRecommendation
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/mysql/single-server/concepts-data-access-security-private-link",
 		},
 		"mysql-006": {
@@ -60,9 +62,9 @@ func (a *MySQLScanner) GetRecommendations() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armmysql.Server)
-				caf := strings.HasPrefix(*c.Name, "mysql")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.DBforMySQL/servers", "mysql", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"mysql-007": {
@@ -84,8 +86,9 @@ func (a *MySQLScanner) GetRecommendations() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armmysql.Server)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -105,6 +108,7 @@ func (a *MySQLFlexibleScanner) GetRecommendations() map[string]models.AzqrRecomm
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/mysql/flexible-server/tutorial-query-performance-insights#set-up-diagnostics",
 		},
 		"mysqlf-003": {
@@ -126,6 +130,7 @@ func (a *MySQLFlexibleScanner) GetRecommendations() map[string]models.AzqrRecomm
 				}
 				return false, sla
 			},
+			Evidence:     []string{"Properties.HighAvailability.Mode", "Properties.HighAvailability.StandbyAvailabilityZone", "Properties.AvailabilityZone"},
 			LearnMoreUrl: "hhttps://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
 		},
 		"mysqlf-004": {
@@ -139,6 +144,7 @@ func (a *MySQLFlexibleScanner) GetRecommendations() map[string]models.AzqrRecomm
 				pe := *i.Properties.Network.PublicNetworkAccess == armmysqlflexibleservers.EnableStatusEnumDisabled
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.Network.PublicNetworkAccess"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/mysql/flexible-server/how-to-manage-virtual-network-cli",
 		},
 		"mysqlf-006": {
@@ -149,9 +155,9 @@ func (a *MySQLFlexibleScanner) GetRecommendations() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armmysqlflexibleservers.Server)
-				caf := strings.HasPrefix(*c.Name, "mysql")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.DBforMySQL/flexibleServers", "mysql", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"mysqlf-007": {
@@ -162,8 +168,9 @@ func (a *MySQLFlexibleScanner) GetRecommendations() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armmysqlflexibleservers.Server)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -24,6 +24,7 @@ func (a *NatGatewayScanner) GetRecommendations() map[string]models.AzqrRecommend
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/nat-gateway/nat-metrics",
 		},
 		"ng-003": {
@@ -46,9 +47,9 @@ func (a *NatGatewayScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.NatGateway)
-				caf := strings.HasPrefix(*c.Name, "ng")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Network/natGateways", "ng", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"ng-007": {
@@ -59,8 +60,9 @@ func (a *NatGatewayScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.NatGateway)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -24,6 +24,7 @@ func (a *NSGScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/virtual-network/virtual-network-nsg-manage-log",
 		},
 		"nsg-003": {
@@ -46,9 +47,9 @@ func (a *NSGScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.SecurityGroup)
-				caf := strings.HasPrefix(*c.Name, "nsg")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Network/networkSecurityGroups", "nsg", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"nsg-007": {
@@ -59,8 +60,9 @@ func (a *NSGScanner) GetRecommendations() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.SecurityGroup)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -25,6 +25,7 @@ func (a *PostgreScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/postgresql/single-server/concepts-server-logs#resource-logs",
 		},
 		"psql-003": {
@@ -50,6 +51,7 @@ func (a *PostgreScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/postgresql/single-server/concepts-data-access-and-security-private-link",
 		},
 		"psql-006": {
@@ -60,9 +62,9 @@ func (a *PostgreScanner) GetRecommendations() map[string]models.AzqrRecommendati
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armpostgresql.Server)
-				caf := strings.HasPrefix(*c.Name, "psql")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.DBforPostgreSQL/servers", "psql", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"psql-007": {
@@ -73,8 +75,9 @@ func (a *PostgreScanner) GetRecommendations() map[string]models.AzqrRecommendati
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armpostgresql.Server)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"psql-008": {
@@ -87,6 +90,7 @@ func (a *PostgreScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				c := target.(*armpostgresql.Server)
 				return c.Properties.SSLEnforcement == nil || *c.Properties.SSLEnforcement == armpostgresql.SSLEnforcementEnumDisabled, ""
 			},
+			Evidence:     []string{"Properties.SSLEnforcement"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/postgresql/single-server/concepts-ssl-connection-security#enforcing-tls-connections",
 		},
 		"psql-009": {
@@ -99,6 +103,7 @@ func (a *PostgreScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				c := target.(*armpostgresql.Server)
 				return c.Properties.MinimalTLSVersion == nil || *c.Properties.MinimalTLSVersion != armpostgresql.MinimalTLSVersionEnumTLS12, ""
 			},
+			Evidence:     []string{"Properties.MinimalTLSVersion"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/postgresql/single-server/how-to-tls-configurations",
 		},
 	}
@@ -118,6 +123,7 @@ func (a *PostgreFlexibleScanner) GetRecommendations() map[string]models.AzqrReco
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/postgresql/flexible-server/howto-configure-and-access-logs",
 		},
 		"psqlf-003": {
@@ -139,6 +145,7 @@ func (a *PostgreFlexibleScanner) GetRecommendations() map[string]models.AzqrReco
 				}
 				return false, sla
 			},
+			Evidence:     []string{"Properties.HighAvailability.Mode", "Properties.HighAvailability.StandbyAvailabilityZone", "Properties.AvailabilityZone"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/postgresql/flexible-server/concepts-compare-single-server-flexible-server",
 		},
 		"psqlf-004": {
@@ -152,6 +159,7 @@ func (a *PostgreFlexibleScanner) GetRecommendations() map[string]models.AzqrReco
 				pe := *i.Properties.Network.PublicNetworkAccess == armpostgresqlflexibleservers.ServerPublicNetworkAccessStateDisabled
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.Network.PublicNetworkAccess"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/postgresql/flexible-server/concepts-networking#private-access-vnet-integration",
 		},
 		"psqlf-006": {
@@ -162,9 +170,9 @@ func (a *PostgreFlexibleScanner) GetRecommendations() map[string]models.AzqrReco
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armpostgresqlflexibleservers.Server)
-				caf := strings.HasPrefix(*c.Name, "psql")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.DBforPostgreSQL/flexibleServers", "psql", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"psqlf-007": {
@@ -175,8 +183,9 @@ func (a *PostgreFlexibleScanner) GetRecommendations() map[string]models.AzqrReco
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armpostgresqlflexibleservers.Server)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
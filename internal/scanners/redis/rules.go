@@ -24,6 +24,7 @@ func (a *RedisScanner) GetRecommendations() map[string]models.AzqrRecommendation
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-monitor-diagnostic-settings",
 		},
 		"redis-003": {
@@ -46,9 +47,9 @@ func (a *RedisScanner) GetRecommendations() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armredis.ResourceInfo)
-				caf := strings.HasPrefix(*c.Name, "redis")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Cache/Redis", "redis", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"redis-007": {
@@ -59,8 +60,9 @@ func (a *RedisScanner) GetRecommendations() map[string]models.AzqrRecommendation
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armredis.ResourceInfo)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"redis-008": {
@@ -73,6 +75,7 @@ func (a *RedisScanner) GetRecommendations() map[string]models.AzqrRecommendation
 				c := target.(*armredis.ResourceInfo)
 				return c.Properties.EnableNonSSLPort != nil && *c.Properties.EnableNonSSLPort, ""
 			},
+			Evidence:     []string{"Properties.EnableNonSSLPort"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-configure#access-ports",
 		},
 		"redis-009": {
@@ -85,6 +88,7 @@ func (a *RedisScanner) GetRecommendations() map[string]models.AzqrRecommendation
 				c := target.(*armredis.ResourceInfo)
 				return c.Properties.MinimumTLSVersion == nil || *c.Properties.MinimumTLSVersion != armredis.TLSVersionOne2, ""
 			},
+			Evidence:     []string{"Properties.MinimumTLSVersion"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-remove-tls-10-11",
 		},
 	}
@@ -6,23 +6,36 @@ import (
 
 	"github.com/Azure/azqr/internal/graph"
 	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/rs/zerolog/log"
 )
 
 type ResourceScanner struct{}
 
-func (sc ResourceScanner) GetAllResources(ctx context.Context, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters) ([]*models.Resource, []*models.Resource) {
+func (sc ResourceScanner) GetAllResources(ctx context.Context, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters, options *arm.ClientOptions) ([]*models.Resource, []*models.Resource, error) {
 	models.LogResourceTypeScan("Resources")
 
-	graphClient := graph.NewGraphQuery(cred)
-	query := "resources | project id, subscriptionId, resourceGroup, location, type, name, sku.name, sku.tier, kind"
+	graphClient, err := graph.NewGraphQuery(cred, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	types := make([]string, 0, len(models.IndexedPropertyTypes))
+	for _, t := range models.IndexedPropertyTypes {
+		types = append(types, "'"+strings.ToLower(t)+"'")
+	}
+	query := "resources | project id, subscriptionId, resourceGroup, location, type, name, sku.name, sku.tier, kind, tags, " +
+		"properties = iff(type in~ (" + strings.Join(types, ", ") + "), properties, dynamic(null))"
 	log.Debug().Msg(query)
 	subs := make([]*string, 0, len(subscriptions))
 	for s := range subscriptions {
 		subs = append(subs, &s)
 	}
-	result := graphClient.Query(ctx, query, subs)
+	result, err := graphClient.Query(ctx, query, subs)
+	if err != nil {
+		return nil, nil, err
+	}
 	resources := []*models.Resource{}
 	excludedResources := []*models.Resource{}
 	if result.Data != nil {
@@ -54,6 +67,16 @@ func (sc ResourceScanner) GetAllResources(ctx context.Context, cred azcore.Token
 				location = m["location"].(string)
 			}
 
+			tags := map[string]*string{}
+			if t, ok := m["tags"].(map[string]interface{}); ok {
+				for k, v := range t {
+					value := to.String(v)
+					tags[k] = &value
+				}
+			}
+
+			properties, _ := m["properties"].(map[string]interface{})
+
 			if filters.Azqr.IsServiceExcluded(m["id"].(string)) {
 				excludedResources = append(
 					excludedResources,
@@ -66,7 +89,9 @@ func (sc ResourceScanner) GetAllResources(ctx context.Context, cred azcore.Token
 						Name:           m["name"].(string),
 						SkuName:        skuName,
 						SkuTier:        skuTier,
-						Kind:           kind})
+						Kind:           kind,
+						Tags:           tags,
+						Properties:     properties})
 
 				continue
 			}
@@ -82,23 +107,31 @@ func (sc ResourceScanner) GetAllResources(ctx context.Context, cred azcore.Token
 					Name:           m["name"].(string),
 					SkuName:        skuName,
 					SkuTier:        skuTier,
-					Kind:           kind})
+					Kind:           kind,
+					Tags:           tags,
+					Properties:     properties})
 		}
 	}
-	return resources, excludedResources
+	return resources, excludedResources, nil
 }
 
-func (sc ResourceScanner) GetCountPerResourceTypeAndSubscription(ctx context.Context, cred azcore.TokenCredential, subscriptions map[string]string, recommendations map[string]map[string]models.AprlRecommendation, filters *models.Filters) []models.ResourceTypeCount {
+func (sc ResourceScanner) GetCountPerResourceTypeAndSubscription(ctx context.Context, cred azcore.TokenCredential, subscriptions map[string]string, recommendations map[string]map[string]models.AprlRecommendation, filters *models.Filters, options *arm.ClientOptions) ([]models.ResourceTypeCount, error) {
 	models.LogResourceTypeScan("Resource Count per Subscription and Type")
 
-	graphClient := graph.NewGraphQuery(cred)
+	graphClient, err := graph.NewGraphQuery(cred, options)
+	if err != nil {
+		return nil, err
+	}
 	query := "resources | summarize count() by subscriptionId, type | order by subscriptionId, type"
 	log.Debug().Msg(query)
 	subs := make([]*string, 0, len(subscriptions))
 	for s := range subscriptions {
 		subs = append(subs, &s)
 	}
-	result := graphClient.Query(ctx, query, subs)
+	result, err := graphClient.Query(ctx, query, subs)
+	if err != nil {
+		return nil, err
+	}
 	resources := []models.ResourceTypeCount{}
 	if result.Data != nil {
 		for _, row := range result.Data {
@@ -119,20 +152,26 @@ func (sc ResourceScanner) GetCountPerResourceTypeAndSubscription(ctx context.Con
 			})
 		}
 	}
-	return resources
+	return resources, nil
 }
 
-func (sc ResourceScanner) GetCountPerResourceType(ctx context.Context, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters) map[string]float64 {
+func (sc ResourceScanner) GetCountPerResourceType(ctx context.Context, cred azcore.TokenCredential, subscriptions map[string]string, filters *models.Filters, options *arm.ClientOptions) (map[string]float64, error) {
 	models.LogResourceTypeScan("Resource Count per Type")
 
-	graphClient := graph.NewGraphQuery(cred)
+	graphClient, err := graph.NewGraphQuery(cred, options)
+	if err != nil {
+		return nil, err
+	}
 	query := "resources | summarize count() by type | order by type"
 	log.Debug().Msg(query)
 	subs := make([]*string, 0, len(subscriptions))
 	for s := range subscriptions {
 		subs = append(subs, &s)
 	}
-	result := graphClient.Query(ctx, query, subs)
+	result, err := graphClient.Query(ctx, query, subs)
+	if err != nil {
+		return nil, err
+	}
 	resources := map[string]float64{}
 	if result.Data != nil {
 		for _, row := range result.Data {
@@ -145,7 +184,7 @@ func (sc ResourceScanner) GetCountPerResourceType(ctx context.Context, cred azco
 			resources[m["type"].(string)] = m["count_"].(float64)
 		}
 	}
-	return resources
+	return resources, nil
 }
 
 func (sc ResourceScanner) isAvailableInAPRL(resourceType string, recommendations map[string]map[string]models.AprlRecommendation) string {
@@ -5,6 +5,7 @@ package rg
 
 import (
 	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 )
 
 func init() {
@@ -14,23 +15,59 @@ func init() {
 // ResourceGroupScanner - Scanner for Resource Groups
 type ResourceGroupScanner struct {
 	config *models.ScannerConfig
+	client *armresources.ResourceGroupsClient
 }
 
 // Init - Initializes the Resource Groups Scanner
 func (a *ResourceGroupScanner) Init(config *models.ScannerConfig) error {
 	a.config = config
-	return nil
+	var err error
+	a.client, err = armresources.NewResourceGroupsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	return err
 }
 
 // Scan - Scans all Resource Groups
 func (a *ResourceGroupScanner) Scan(scanContext *models.ScanContext) ([]models.AzqrServiceResult, error) {
-	return []models.AzqrServiceResult{}, nil
+	models.LogSubscriptionScan(a.config.SubscriptionID, a.ResourceTypes()[0])
+
+	groups, err := a.list()
+	if err != nil {
+		return nil, err
+	}
+	engine := models.RecommendationEngine{}
+	rules := a.GetRecommendations()
+	results := []models.AzqrServiceResult{}
+
+	for _, g := range groups {
+		rr := engine.EvaluateRecommendations(rules, g, scanContext)
+
+		results = append(results, models.AzqrServiceResult{
+			SubscriptionID:   a.config.SubscriptionID,
+			SubscriptionName: a.config.SubscriptionName,
+			ResourceGroup:    *g.Name,
+			ServiceName:      *g.Name,
+			Type:             *g.Type,
+			Location:         *g.Location,
+			Recommendations:  rr,
+		})
+	}
+	return results, nil
 }
 
-func (a *ResourceGroupScanner) ResourceTypes() []string {
-	return []string{"Microsoft.Resources/resourceGroups"}
+func (a *ResourceGroupScanner) list() ([]*armresources.ResourceGroup, error) {
+	pager := a.client.NewListPager(nil)
+
+	groups := make([]*armresources.ResourceGroup, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, resp.Value...)
+	}
+	return groups, nil
 }
 
-func (a *ResourceGroupScanner) GetRecommendations() map[string]models.AzqrRecommendation {
-	return map[string]models.AzqrRecommendation{}
+func (a *ResourceGroupScanner) ResourceTypes() []string {
+	return []string{"Microsoft.Resources/resourceGroups"}
 }
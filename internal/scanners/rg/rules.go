@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package rg
+
+import (
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// GetRecommendations - Returns the rules for the ResourceGroupScanner
+func (a *ResourceGroupScanner) GetRecommendations() map[string]models.AzqrRecommendation {
+	return map[string]models.AzqrRecommendation{
+		"rg-001": {
+			RecommendationID: "rg-001",
+			ResourceType:     "Microsoft.Resources/resourceGroups",
+			Category:         models.CategoryGovernance,
+			Recommendation:   "Resource Group should have tags",
+			Impact:           models.ImpactLow,
+			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
+				c := target.(*armresources.ResourceGroup)
+				return scanContext.CheckTags(c.ID, c.Tags)
+			},
+			Evidence:     []string{"Tags"},
+			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+		},
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package rg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+func TestResourceGroupScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *models.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "ResourceGroupScanner no tags",
+			fields: fields{
+				rule: "rg-001",
+				target: &armresources.ResourceGroup{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg"),
+				},
+				scanContext: &models.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner tags",
+			fields: fields{
+				rule: "rg-001",
+				target: &armresources.ResourceGroup{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg"),
+					Tags: map[string]*string{
+						"env": to.Ptr("prod"),
+					},
+				},
+				scanContext: &models.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ResourceGroupScanner{}
+			rules := s.GetRecommendations()
+			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceGroupScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// ResourceGroupTagsScanner - Scanner for the tags of Resource Groups, inherited by their resources
+type ResourceGroupTagsScanner struct {
+	config *models.ScannerConfig
+	client *armresources.ResourceGroupsClient
+}
+
+// Init - Initializes the ResourceGroupTagsScanner
+func (s *ResourceGroupTagsScanner) Init(config *models.ScannerConfig) error {
+	s.config = config
+	var err error
+	s.client, err = armresources.NewResourceGroupsClient(s.config.SubscriptionID, s.config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListResourceGroupTags - Lists the tags of all Resource Groups, per lower case Resource Group ID
+func (s *ResourceGroupTagsScanner) ListResourceGroupTags() (map[string]map[string]*string, error) {
+	models.LogSubscriptionScan(s.config.SubscriptionID, "Resource Group Tags")
+
+	res := map[string]map[string]*string{}
+	pager := s.client.NewListPager(nil)
+
+	for pager.More() {
+		resp, err := pager.NextPage(s.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range resp.Value {
+			res[strings.ToLower(*v.ID)] = v.Tags
+		}
+	}
+
+	return res, nil
+}
+
+func (s *ResourceGroupTagsScanner) Scan(config *models.ScannerConfig) (map[string]map[string]*string, error) {
+	err := s.Init(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Resource Group Tags Scanner: %w", err)
+	}
+	tags, err := s.ListResourceGroupTags()
+	if err != nil {
+		if models.ShouldSkipError(err) {
+			return map[string]map[string]*string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list Resource Group Tags: %w", err)
+	}
+	return tags, nil
+}
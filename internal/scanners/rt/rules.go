@@ -4,8 +4,6 @@
 package rt
 
 import (
-	"strings"
-
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
 )
@@ -33,9 +31,9 @@ func (a *RouteTableScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.RouteTable)
-				caf := strings.HasPrefix(*c.Name, "rt")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Network/routeTables", "rt", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"udr-007": {
@@ -46,8 +44,9 @@ func (a *RouteTableScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.RouteTable)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
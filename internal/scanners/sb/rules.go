@@ -24,6 +24,7 @@ func (a *ServiceBusScanner) GetRecommendations() map[string]models.AzqrRecommend
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/monitor-service-bus#collection-and-routing",
 		},
 		"sb-003": {
@@ -42,6 +43,7 @@ func (a *ServiceBusScanner) GetRecommendations() map[string]models.AzqrRecommend
 				}
 				return false, sla
 			},
+			Evidence:     []string{"SKU.Name"},
 			LearnMoreUrl: "https://www.azure.cn/en-us/support/sla/service-bus/",
 		},
 		"sb-004": {
@@ -55,6 +57,7 @@ func (a *ServiceBusScanner) GetRecommendations() map[string]models.AzqrRecommend
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/network-security",
 		},
 		"sb-006": {
@@ -65,9 +68,9 @@ func (a *ServiceBusScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armservicebus.SBNamespace)
-				caf := strings.HasPrefix(*c.Name, "sb")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.ServiceBus/namespaces", "sb", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"sb-007": {
@@ -78,8 +81,9 @@ func (a *ServiceBusScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armservicebus.SBNamespace)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"sb-008": {
@@ -93,6 +97,7 @@ func (a *ServiceBusScanner) GetRecommendations() map[string]models.AzqrRecommend
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
 				return !localAuth, ""
 			},
+			Evidence:     []string{"Properties.DisableLocalAuth"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-sas",
 		},
 	}
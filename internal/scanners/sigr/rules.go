@@ -24,6 +24,7 @@ func (a *SignalRScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-signalr/signalr-howto-diagnostic-logs",
 		},
 		"sigr-003": {
@@ -49,6 +50,7 @@ func (a *SignalRScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-signalr/howto-private-endpoints",
 		},
 		"sigr-006": {
@@ -59,9 +61,9 @@ func (a *SignalRScanner) GetRecommendations() map[string]models.AzqrRecommendati
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsignalr.ResourceInfo)
-				caf := strings.HasPrefix(*c.Name, "sigr")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.SignalRService/SignalR", "sigr", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"sigr-007": {
@@ -72,8 +74,9 @@ func (a *SignalRScanner) GetRecommendations() map[string]models.AzqrRecommendati
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsignalr.ResourceInfo)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
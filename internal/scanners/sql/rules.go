@@ -35,6 +35,7 @@ func (a *SQLScanner) getServerRules() map[string]models.AzqrRecommendation {
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence: []string{"Properties.PrivateEndpointConnections"},
 		},
 		"sql-006": {
 			RecommendationID: "sql-006",
@@ -44,9 +45,9 @@ func (a *SQLScanner) getServerRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsql.Server)
-				caf := strings.HasPrefix(*c.Name, "sql")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Sql/servers", "sql", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"sql-007": {
@@ -57,8 +58,9 @@ func (a *SQLScanner) getServerRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsql.Server)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"sql-008": {
@@ -71,6 +73,7 @@ func (a *SQLScanner) getServerRules() map[string]models.AzqrRecommendation {
 				c := target.(*armsql.Server)
 				return c.Properties.MinimalTLSVersion == nil || *c.Properties.MinimalTLSVersion != "1.2", ""
 			},
+			Evidence:     []string{"Properties.MinimalTLSVersion"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-sql/database/connectivity-settings?view=azuresql&tabs=azure-portal#minimal-tls-version",
 		},
 	}
@@ -89,6 +92,7 @@ func (a *SQLScanner) getDatabaseRules() map[string]models.AzqrRecommendation {
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence: []string{"scanContext.DiagnosticsSettings"},
 		},
 		"sqldb-003": {
 			RecommendationID:   "sqldb-003",
@@ -105,6 +109,7 @@ func (a *SQLScanner) getDatabaseRules() map[string]models.AzqrRecommendation {
 				}
 				return false, sla
 			},
+			Evidence: []string{"Properties.ZoneRedundant", "SKU.Tier"},
 		},
 		"sqldb-006": {
 			RecommendationID: "sqldb-006",
@@ -114,9 +119,12 @@ func (a *SQLScanner) getDatabaseRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsql.Database)
-				caf := *c.Name == "master" || strings.HasPrefix(*c.Name, "sqldb")
-				return !caf, ""
+				if *c.Name == "master" {
+					return false, ""
+				}
+				return scanContext.CheckNaming("Microsoft.Sql/servers/databases", "sqldb", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"sqldb-007": {
@@ -127,8 +135,9 @@ func (a *SQLScanner) getDatabaseRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsql.Database)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -144,9 +153,9 @@ func (a *SQLScanner) getPoolRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsql.ElasticPool)
-				caf := strings.HasPrefix(*c.Name, "sqlep")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Sql/servers/elasticPools", "sqlep", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"sqlep-003": {
@@ -157,8 +166,9 @@ func (a *SQLScanner) getPoolRules() map[string]models.AzqrRecommendation {
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsql.ElasticPool)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
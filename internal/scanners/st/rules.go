@@ -24,6 +24,7 @@ func (a *StorageScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/storage/blobs/monitor-blob-storage",
 		},
 		"st-003": {
@@ -52,6 +53,7 @@ func (a *StorageScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				}
 				return false, sla
 			},
+			Evidence:     []string{"SKU.Name", "Properties.AccessTier"},
 			LearnMoreUrl: "https://www.azure.cn/en-us/support/sla/storage/",
 		},
 		"st-006": {
@@ -62,9 +64,9 @@ func (a *StorageScanner) GetRecommendations() map[string]models.AzqrRecommendati
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armstorage.Account)
-				caf := strings.HasPrefix(*c.Name, "st")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Storage/storageAccounts", "st", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"st-007": {
@@ -78,6 +80,7 @@ func (a *StorageScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				h := *c.Properties.EnableHTTPSTrafficOnly
 				return !h, ""
 			},
+			Evidence:     []string{"Properties.EnableHTTPSTrafficOnly"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/storage/common/storage-require-secure-transfer",
 		},
 		"st-008": {
@@ -88,8 +91,9 @@ func (a *StorageScanner) GetRecommendations() map[string]models.AzqrRecommendati
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armstorage.Account)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"st-009": {
@@ -102,6 +106,7 @@ func (a *StorageScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				c := target.(*armstorage.Account)
 				return c.Properties.MinimumTLSVersion == nil || *c.Properties.MinimumTLSVersion != armstorage.MinimumTLSVersionTLS12, ""
 			},
+			Evidence:     []string{"Properties.MinimumTLSVersion"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/storage/common/transport-layer-security-configure-minimum-version?tabs=portal",
 		},
 		"st-010": {
@@ -114,6 +119,7 @@ func (a *StorageScanner) GetRecommendations() map[string]models.AzqrRecommendati
 				c := target.(*armstorage.Account)
 				return c.Properties.ImmutableStorageWithVersioning == nil || c.Properties.ImmutableStorageWithVersioning.Enabled == nil || !*c.Properties.ImmutableStorageWithVersioning.Enabled, ""
 			},
+			Evidence:     []string{"Properties.ImmutableStorageWithVersioning.Enabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/storage-accounts/reliability",
 		},
 		"st-011": {
@@ -130,6 +136,7 @@ func (a *StorageScanner) GetRecommendations() map[string]models.AzqrRecommendati
 
 				return broken, ""
 			},
+			Evidence:     []string{"scanContext.BlobServiceProperties.BlobServiceProperties.BlobServiceProperties.ContainerDeleteRetentionPolicy.Enabled"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/storage-accounts/reliability",
 		},
 	}
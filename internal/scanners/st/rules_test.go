@@ -153,7 +153,7 @@ func TestStorageScanner_Rules(t *testing.T) {
 				target: &armstorage.Account{
 					Properties: &armstorage.AccountProperties{},
 				},
-				scanContext: &models.ScanContext{
+				scanContext: (&models.ScanContext{}).WithResource(models.ResourceData{
 					BlobServiceProperties: &armstorage.BlobServicesClientGetServicePropertiesResponse{
 						BlobServiceProperties: armstorage.BlobServiceProperties{
 							BlobServiceProperties: &armstorage.BlobServicePropertiesProperties{
@@ -163,7 +163,7 @@ func TestStorageScanner_Rules(t *testing.T) {
 							},
 						},
 					},
-				},
+				}),
 			},
 			want: want{
 				broken: false,
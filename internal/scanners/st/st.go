@@ -46,13 +46,13 @@ func (c *StorageScanner) Scan(scanContext *models.ScanContext) ([]models.AzqrSer
 	for _, storage := range storage {
 		resourceGroupName := models.GetResourceGroupFromResourceID(*storage.ID)
 
-		scanContext.BlobServiceProperties = nil
+		data := models.ResourceData{}
 		blobServicesProperties, err := c.blobServicesClient.GetServiceProperties(c.config.Ctx, resourceGroupName, *storage.Name, nil)
 		if err == nil {
-			scanContext.BlobServiceProperties = &blobServicesProperties
+			data.BlobServiceProperties = &blobServicesProperties
 		}
 
-		rr := engine.EvaluateRecommendations(rules, storage, scanContext)
+		rr := engine.EvaluateRecommendations(rules, storage, scanContext.WithResource(data))
 
 		results = append(results, models.AzqrServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
@@ -0,0 +1,101 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package st
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/testserver"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+const seed = `
+subscriptions:
+  - id: 00000000-0000-0000-0000-000000000001
+    name: test
+resources:
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/softdelete
+    location: westeurope
+    properties: {}
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/softdelete/blobServices/default
+    properties:
+      containerDeleteRetentionPolicy:
+        enabled: true
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/nosoftdelete
+    location: westeurope
+    properties: {}
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/nosoftdelete/blobServices/default
+    properties:
+      containerDeleteRetentionPolicy:
+        enabled: false
+`
+
+// TestStorageScanner_ConcurrentScans runs scanners concurrently with a shared scan context, as the scan of a subscription does.
+// Run with -race to detect scanners writing to the shared scan context.
+func TestStorageScanner_ConcurrentScans(t *testing.T) {
+	s, err := testserver.ParseSeed([]byte(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := testserver.New(s)
+	defer server.Close()
+
+	config := &models.ScannerConfig{
+		Ctx:  context.Background(),
+		Cred: server.Credential(),
+		ClientOptions: &arm.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Transport: server.Transport(),
+				Retry:     policy.RetryOptions{MaxRetries: -1},
+			},
+		},
+		SubscriptionID: "00000000-0000-0000-0000-000000000001",
+	}
+	scanContext := &models.ScanContext{
+		Filters:             models.NewFilters(),
+		DiagnosticsSettings: map[string]bool{},
+	}
+
+	const scans = 8
+	results := make([][]models.AzqrServiceResult, scans)
+	errs := make([]error, scans)
+	var wg sync.WaitGroup
+	for i := 0; i < scans; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			scanner := &StorageScanner{}
+			if errs[i] = scanner.Init(config); errs[i] != nil {
+				return
+			}
+			results[i], errs[i] = scanner.Scan(scanContext)
+		}(i)
+	}
+	wg.Wait()
+
+	want := map[string]bool{"softdelete": false, "nosoftdelete": true}
+	for i := 0; i < scans; i++ {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if len(results[i]) != len(want) {
+			t.Fatalf("scan %d returned %d storage accounts, want %d", i, len(results[i]), len(want))
+		}
+		for _, r := range results[i] {
+			t.Run(fmt.Sprintf("%d/%s", i, r.ServiceName), func(t *testing.T) {
+				if got := r.Recommendations["st-011"].NotCompliant; got != want[r.ServiceName] {
+					t.Errorf("%s st-011 = %v, want %v", r.ServiceName, got, want[r.ServiceName])
+				}
+			})
+		}
+	}
+	if scanContext.BlobServiceProperties != nil {
+		t.Error("scanners must not set the resource data of the shared scan context")
+	}
+}
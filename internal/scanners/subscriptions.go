@@ -2,6 +2,7 @@ package scanners
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azqr/internal/to"
@@ -13,10 +14,10 @@ import (
 
 type SubcriptionScanner struct{}
 
-func (sc SubcriptionScanner) ListSubscriptions(ctx context.Context, cred azcore.TokenCredential, subscriptions []string, filters *models.Filters, options *arm.ClientOptions) map[string]string {
+func (sc SubcriptionScanner) ListSubscriptions(ctx context.Context, cred azcore.TokenCredential, subscriptions []string, filters *models.Filters, options *arm.ClientOptions) (map[string]string, error) {
 	client, err := armsubscription.NewSubscriptionsClient(cred, options)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create subscriptions client")
+		return nil, fmt.Errorf("failed to create subscriptions client: %w", err)
 	}
 
 	resultPager := client.NewListPager(nil)
@@ -25,7 +26,7 @@ func (sc SubcriptionScanner) ListSubscriptions(ctx context.Context, cred azcore.
 	for resultPager.More() {
 		pageResp, err := resultPager.NextPage(ctx)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to list subscriptions")
+			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
 		}
 
 		for _, s := range pageResp.Value {
@@ -50,7 +51,7 @@ func (sc SubcriptionScanner) ListSubscriptions(ctx context.Context, cred azcore.
 		}
 	}
 
-	return result
+	return result, nil
 }
 
 // Chek if string is in slice
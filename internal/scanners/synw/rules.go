@@ -34,6 +34,7 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]models.AzqrReco
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/data-factory/monitor-configure-diagnostics",
 		},
 		"synw-002": {
@@ -47,6 +48,7 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]models.AzqrReco
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/synapse-analytics/security/synapse-workspace-managed-private-endpoints",
 		},
 		"synw-003": {
@@ -69,9 +71,9 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]models.AzqrReco
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsynapse.Workspace)
-				caf := strings.HasPrefix(*c.Name, "synw")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Synapse/workspaces", "synw", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"synw-005": {
@@ -82,8 +84,9 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]models.AzqrReco
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsynapse.Workspace)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"synw-006": {
@@ -96,6 +99,7 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]models.AzqrReco
 				c := target.(*armsynapse.Workspace)
 				return c.Properties.ManagedVirtualNetwork == nil || strings.ToLower(*c.Properties.ManagedVirtualNetwork) != "default", ""
 			},
+			Evidence:     []string{"Properties.ManagedVirtualNetwork"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/security/benchmark/azure/baselines/azure-synapse-analytics-security-baseline?toc=%2Fazure%2Fsynapse-analytics%2Ftoc.json",
 		},
 		"synw-007": {
@@ -108,6 +112,7 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]models.AzqrReco
 				c := target.(*armsynapse.Workspace)
 				return string(*c.Properties.PublicNetworkAccess) == "Enabled", ""
 			},
+			Evidence:     []string{"Properties.PublicNetworkAccess"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/security/benchmark/azure/baselines/azure-synapse-analytics-security-baseline?toc=%2Fazure%2Fsynapse-analytics%2Ftoc.json",
 		},
 	}
@@ -123,9 +128,9 @@ func (a *SynapseWorkspaceScanner) getSparkPoolRules() map[string]models.AzqrReco
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsynapse.BigDataPoolResourceInfo)
-				caf := strings.HasPrefix(*c.Name, "synsp")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Synapse workspaces/bigDataPools", "synsp", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"synsp-002": {
@@ -148,8 +153,9 @@ func (a *SynapseWorkspaceScanner) getSparkPoolRules() map[string]models.AzqrReco
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsynapse.BigDataPoolResourceInfo)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -165,9 +171,9 @@ func (a *SynapseWorkspaceScanner) getSqlPoolRules() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsynapse.SQLPool)
-				caf := strings.HasPrefix(*c.Name, "syndp")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Synapse/workspaces/sqlPools", "syndp", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"syndp-002": {
@@ -190,8 +196,9 @@ func (a *SynapseWorkspaceScanner) getSqlPoolRules() map[string]models.AzqrRecomm
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armsynapse.SQLPool)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
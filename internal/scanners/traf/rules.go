@@ -25,6 +25,7 @@ func (a *TrafficManagerScanner) GetRecommendations() map[string]models.AzqrRecom
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/traffic-manager/traffic-manager-diagnostic-logs",
 		},
 		"traf-002": {
@@ -36,6 +37,11 @@ func (a *TrafficManagerScanner) GetRecommendations() map[string]models.AzqrRecom
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				return false, ""
 			},
+			// Traffic Manager profiles are global, so zones only apply to profiles in a region with zones
+			Applies: func(target interface{}, scanContext *models.ScanContext) bool {
+				service := target.(*armtrafficmanager.Profile)
+				return models.HasAvailabilityZones(service.Location)
+			},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/architecture/high-availability/reference-architecture-traffic-manager-application-gateway",
 		},
 		"traf-003": {
@@ -58,9 +64,9 @@ func (a *TrafficManagerScanner) GetRecommendations() map[string]models.AzqrRecom
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armtrafficmanager.Profile)
-				caf := strings.HasPrefix(*c.Name, "traf")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Network/trafficManagerProfiles", "traf", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"traf-007": {
@@ -71,8 +77,9 @@ func (a *TrafficManagerScanner) GetRecommendations() map[string]models.AzqrRecom
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armtrafficmanager.Profile)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"traf-009": {
@@ -86,6 +93,7 @@ func (a *TrafficManagerScanner) GetRecommendations() map[string]models.AzqrRecom
 				httpMonitor := *c.Properties.MonitorConfig.Port == int64(80) || *c.Properties.MonitorConfig.Port == int64(443)
 				return httpMonitor && c.Properties.MonitorConfig.Protocol != to.Ptr(armtrafficmanager.MonitorProtocolHTTPS), ""
 			},
+			Evidence:     []string{"Properties.MonitorConfig.Port", "Properties.MonitorConfig.Protocol"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/traffic-manager/traffic-manager-monitoring",
 		},
 	}
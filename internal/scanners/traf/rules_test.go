@@ -137,3 +137,13 @@ func TestTrafficManagerScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestTrafficManagerScanner_ZonesApplies(t *testing.T) {
+	s := &TrafficManagerScanner{}
+	rule := s.GetRecommendations()["traf-002"]
+	for location, want := range map[string]bool{"westeurope": true, "westcentralus": false, "global": false} {
+		if got := rule.Applies(&armtrafficmanager.Profile{Location: to.Ptr(location)}, &models.ScanContext{}); got != want {
+			t.Errorf("traf-002 Applies() in %s = %v, want %v", location, got, want)
+		}
+	}
+}
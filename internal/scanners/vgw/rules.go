@@ -29,6 +29,7 @@ func (a *VirtualNetworkGatewayScanner) GetVirtualNetworkGatewayRules() map[strin
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/vpn-gateway/monitor-vpn-gateway",
 		},
 		"vgw-002": {
@@ -41,13 +42,14 @@ func (a *VirtualNetworkGatewayScanner) GetVirtualNetworkGatewayRules() map[strin
 				c := target.(*armnetwork.VirtualNetworkGateway)
 				switch *c.Properties.GatewayType {
 				case armnetwork.VirtualNetworkGatewayTypeVPN:
-					return !strings.HasPrefix(*c.Name, "vpng"), ""
+					return scanContext.CheckNaming("Microsoft.Network/virtualNetworkGateways", "vpng", *c.Name)
 				case armnetwork.VirtualNetworkGatewayTypeExpressRoute:
-					return !strings.HasPrefix(*c.Name, "ergw"), ""
+					return scanContext.CheckNaming("Microsoft.Network/virtualNetworkGateways", "ergw", *c.Name)
 				default:
-					return !strings.HasPrefix(*c.Name, "lgw"), ""
+					return scanContext.CheckNaming("Microsoft.Network/virtualNetworkGateways", "lgw", *c.Name)
 				}
 			},
+			Evidence:     []string{"Properties.GatewayType", "Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"vgw-003": {
@@ -58,8 +60,9 @@ func (a *VirtualNetworkGatewayScanner) GetVirtualNetworkGatewayRules() map[strin
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.VirtualNetworkGateway)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"vgw-004": {
@@ -78,6 +81,7 @@ func (a *VirtualNetworkGatewayScanner) GetVirtualNetworkGatewayRules() map[strin
 				}
 				return false, sla
 			},
+			Evidence:     []string{"Properties.SKU.Tier"},
 			LearnMoreUrl: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
 		"vgw-005": {
@@ -91,6 +95,11 @@ func (a *VirtualNetworkGatewayScanner) GetVirtualNetworkGatewayRules() map[strin
 				sku := string(*g.Properties.SKU.Name)
 				return !strings.HasSuffix(strings.ToLower(sku), "az"), ""
 			},
+			Evidence: []string{"Properties.SKU.Name"},
+			Applies: func(target interface{}, scanContext *models.ScanContext) bool {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				return models.HasAvailabilityZones(g.Location)
+			},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/vpn-gateway/create-zone-redundant-vnet-gateway",
 		},
 	}
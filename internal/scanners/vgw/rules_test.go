@@ -146,3 +146,13 @@ func TestVirtualNetworkGatewayScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestVirtualNetworkGatewayScanner_ZonesApplies(t *testing.T) {
+	s := &VirtualNetworkGatewayScanner{}
+	rule := s.GetVirtualNetworkGatewayRules()["vgw-005"]
+	for location, want := range map[string]bool{"westeurope": true, "westcentralus": false} {
+		if got := rule.Applies(&armnetwork.VirtualNetworkGateway{Location: to.Ptr(location)}, &models.ScanContext{}); got != want {
+			t.Errorf("vgw-005 Applies() in %s = %v, want %v", location, got, want)
+		}
+	}
+}
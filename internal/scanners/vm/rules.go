@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
 )
 
 // GetRecommendations - Returns the rules for the VirtualMachineScanner
@@ -33,6 +34,7 @@ func (a *VirtualMachineScanner) GetRecommendations() map[string]models.AzqrRecom
 				}
 				return false, sla
 			},
+			Evidence:     []string{"Properties.VirtualMachineScaleSet.ID", "Zones"},
 			LearnMoreUrl: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
 		},
 		"vm-006": {
@@ -43,9 +45,9 @@ func (a *VirtualMachineScanner) GetRecommendations() map[string]models.AzqrRecom
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcompute.VirtualMachine)
-				caf := strings.HasPrefix(*c.Name, "vm")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Compute/virtualMachines", "vm", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"vm-007": {
@@ -56,9 +58,70 @@ func (a *VirtualMachineScanner) GetRecommendations() map[string]models.AzqrRecom
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcompute.VirtualMachine)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
+		"vm-008": {
+			RecommendationID: "vm-008",
+			ResourceType:     "Microsoft.Compute/virtualMachines",
+			Category:         models.CategorySecurity,
+			Recommendation:   "Virtual Machine network interfaces should be protected by a Network Security Group",
+			Impact:           models.ImpactMedium,
+			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
+				c := target.(*armcompute.VirtualMachine)
+				unprotected := unprotectedInterfaces(c, scanContext.Resources)
+				return len(unprotected) > 0, strings.Join(unprotected, ", ")
+			},
+			Evidence:     []string{"Properties.NetworkProfile.NetworkInterfaces"},
+			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/virtual-network/network-security-groups-overview",
+		},
+	}
+}
+
+// unprotectedInterfaces returns the names of the network interfaces of the virtual machine without a network security
+// group, on the interface or on the subnets of its IP configurations. Resources not in the inventory are ignored.
+func unprotectedInterfaces(v *armcompute.VirtualMachine, resources *models.ResourceIndex) []string {
+	names := []string{}
+	if v.Properties == nil || v.Properties.NetworkProfile == nil {
+		return names
+	}
+
+	for _, ref := range v.Properties.NetworkProfile.NetworkInterfaces {
+		if ref == nil || ref.ID == nil {
+			continue
+		}
+		nic, ok := resources.Get(*ref.ID)
+		var properties armnetwork.InterfacePropertiesFormat
+		if !ok || !nic.DecodeProperties(&properties) || properties.NetworkSecurityGroup != nil {
+			continue
+		}
+
+		for _, ipConfig := range properties.IPConfigurations {
+			if ipConfig == nil || ipConfig.Properties == nil || ipConfig.Properties.Subnet == nil || ipConfig.Properties.Subnet.ID == nil {
+				continue
+			}
+			if !subnetHasNetworkSecurityGroup(*ipConfig.Properties.Subnet.ID, resources) {
+				names = append(names, nic.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+func subnetHasNetworkSecurityGroup(subnetID string, resources *models.ResourceIndex) bool {
+	vnet, ok := resources.Owner(subnetID)
+	var properties armnetwork.VirtualNetworkPropertiesFormat
+	if !ok || !vnet.DecodeProperties(&properties) {
+		return true
+	}
+
+	for _, subnet := range properties.Subnets {
+		if subnet != nil && subnet.ID != nil && strings.EqualFold(*subnet.ID, subnetID) {
+			return subnet.Properties != nil && subnet.Properties.NetworkSecurityGroup != nil
+		}
 	}
+	return true
 }
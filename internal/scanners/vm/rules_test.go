@@ -67,6 +67,42 @@ func TestVirtualMachineScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "VirtualMachineScanner network interfaces protected by a Network Security Group",
+			fields: fields{
+				rule:        "vm-008",
+				target:      virtualMachine(rg+"/providers/Microsoft.Network/networkInterfaces/nic-nsg", rg+"/providers/Microsoft.Network/networkInterfaces/nic-protected"),
+				scanContext: &models.ScanContext{Resources: networkIndex()},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualMachineScanner network interface in a subnet without a Network Security Group",
+			fields: fields{
+				rule:        "vm-008",
+				target:      virtualMachine(rg+"/providers/Microsoft.Network/networkInterfaces/nic-nsg", rg+"/providers/Microsoft.Network/networkInterfaces/nic-open"),
+				scanContext: &models.ScanContext{Resources: networkIndex()},
+			},
+			want: want{
+				broken: true,
+				result: "nic-open",
+			},
+		},
+		{
+			name: "VirtualMachineScanner network interface not in the inventory",
+			fields: fields{
+				rule:        "vm-008",
+				target:      virtualMachine(rg + "/providers/Microsoft.Network/networkInterfaces/unknown"),
+				scanContext: &models.ScanContext{Resources: networkIndex()},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -83,3 +119,52 @@ func TestVirtualMachineScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+const rg = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"
+
+func virtualMachine(nics ...string) *armcompute.VirtualMachine {
+	refs := []*armcompute.NetworkInterfaceReference{}
+	for _, nic := range nics {
+		refs = append(refs, &armcompute.NetworkInterfaceReference{ID: to.Ptr(nic)})
+	}
+	return &armcompute.VirtualMachine{
+		Properties: &armcompute.VirtualMachineProperties{
+			NetworkProfile: &armcompute.NetworkProfile{NetworkInterfaces: refs},
+		},
+	}
+}
+
+// networkIndex returns an inventory with a virtual network with a protected and an open subnet,
+// and network interfaces protected by their own Network Security Group or in either subnet
+func networkIndex() *models.ResourceIndex {
+	const vnet = rg + "/providers/Microsoft.Network/virtualNetworks/vnet"
+	const nsg = rg + "/providers/Microsoft.Network/networkSecurityGroups/nsg"
+	nic := func(name, subnet string, properties map[string]interface{}) *models.Resource {
+		properties["ipConfigurations"] = []interface{}{
+			map[string]interface{}{"properties": map[string]interface{}{"subnet": map[string]interface{}{"id": vnet + "/subnets/" + subnet}}},
+		}
+		return &models.Resource{
+			ID:         rg + "/providers/Microsoft.Network/networkInterfaces/" + name,
+			Name:       name,
+			Type:       "Microsoft.Network/networkInterfaces",
+			Properties: properties,
+		}
+	}
+
+	return models.NewResourceIndex([]*models.Resource{
+		{
+			ID:   vnet,
+			Name: "vnet",
+			Type: "Microsoft.Network/virtualNetworks",
+			Properties: map[string]interface{}{
+				"subnets": []interface{}{
+					map[string]interface{}{"id": vnet + "/subnets/protected", "properties": map[string]interface{}{"networkSecurityGroup": map[string]interface{}{"id": nsg}}},
+					map[string]interface{}{"id": vnet + "/subnets/open", "properties": map[string]interface{}{}},
+				},
+			},
+		},
+		nic("nic-nsg", "open", map[string]interface{}{"networkSecurityGroup": map[string]interface{}{"id": nsg}}),
+		nic("nic-protected", "protected", map[string]interface{}{}),
+		nic("nic-open", "open", map[string]interface{}{}),
+	})
+}
@@ -4,8 +4,6 @@
 package vmss
 
 import (
-	"strings"
-
 	"github.com/Azure/azqr/internal/models"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
 )
@@ -29,6 +27,7 @@ func (a *VirtualMachineScaleSetScanner) GetRecommendations() map[string]models.A
 				}
 				return false, sla
 			},
+			Evidence:     []string{"Zones"},
 			LearnMoreUrl: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
 		},
 		"vmss-004": {
@@ -39,9 +38,9 @@ func (a *VirtualMachineScaleSetScanner) GetRecommendations() map[string]models.A
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcompute.VirtualMachineScaleSet)
-				caf := strings.HasPrefix(*c.Name, "vmss")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Compute/virtualMachineScaleSets", "vmss", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"vmss-005": {
@@ -52,8 +51,9 @@ func (a *VirtualMachineScaleSetScanner) GetRecommendations() map[string]models.A
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armcompute.VirtualMachineScaleSet)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
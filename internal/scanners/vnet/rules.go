@@ -24,6 +24,7 @@ func (a *VirtualNetworkScanner) GetRecommendations() map[string]models.AzqrRecom
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/virtual-network/monitor-virtual-network#collection-and-routing",
 		},
 		"vnet-006": {
@@ -34,9 +35,9 @@ func (a *VirtualNetworkScanner) GetRecommendations() map[string]models.AzqrRecom
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.VirtualNetwork)
-				caf := strings.HasPrefix(*c.Name, "vnet")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Network/virtualNetworks", "vnet", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"vnet-007": {
@@ -47,8 +48,9 @@ func (a *VirtualNetworkScanner) GetRecommendations() map[string]models.AzqrRecom
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.VirtualNetwork)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 		"vnet-009": {
@@ -64,6 +66,7 @@ func (a *VirtualNetworkScanner) GetRecommendations() map[string]models.AzqrRecom
 				}
 				return len(c.Properties.DhcpOptions.DNSServers) < 2, ""
 			},
+			Evidence:     []string{"Properties.DhcpOptions.DNSServers"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/virtual-network/virtual-networks-name-resolution-for-vms-and-role-instances?tabs=redhat#specify-dns-servers",
 		},
 	}
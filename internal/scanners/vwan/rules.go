@@ -24,6 +24,7 @@ func (a *VirtualWanScanner) GetRecommendations() map[string]models.AzqrRecommend
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/virtual-wan/monitor-virtual-wan",
 		},
 		"vwa-002": {
@@ -35,6 +36,10 @@ func (a *VirtualWanScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				return false, ""
 			},
+			Applies: func(target interface{}, scanContext *models.ScanContext) bool {
+				service := target.(*armnetwork.VirtualWAN)
+				return models.HasAvailabilityZones(service.Location)
+			},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/virtual-wan/virtual-wan-faq#how-are-availability-zones-and-resiliency-handled-in-virtual-wan",
 		},
 		"vwa-003": {
@@ -59,6 +64,7 @@ func (a *VirtualWanScanner) GetRecommendations() map[string]models.AzqrRecommend
 				i := target.(*armnetwork.VirtualWAN)
 				return false, string(*i.Properties.Type)
 			},
+			Evidence:     []string{"Properties.Type"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/virtual-wan/virtual-wan-about#basicstandard",
 		},
 		"vwa-006": {
@@ -69,9 +75,9 @@ func (a *VirtualWanScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.VirtualWAN)
-				caf := strings.HasPrefix(*c.Name, "vwa")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.Network/virtualWans", "vwa", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"vwa-007": {
@@ -82,8 +88,9 @@ func (a *VirtualWanScanner) GetRecommendations() map[string]models.AzqrRecommend
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armnetwork.VirtualWAN)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
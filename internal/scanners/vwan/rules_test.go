@@ -99,3 +99,13 @@ func TestVirtualWanScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestVirtualWanScanner_ZonesApplies(t *testing.T) {
+	s := &VirtualWanScanner{}
+	rule := s.GetRecommendations()["vwa-002"]
+	for location, want := range map[string]bool{"westeurope": true, "westcentralus": false} {
+		if got := rule.Applies(&armnetwork.VirtualWAN{Location: to.Ptr(location)}, &models.ScanContext{}); got != want {
+			t.Errorf("vwa-002 Applies() in %s = %v, want %v", location, got, want)
+		}
+	}
+}
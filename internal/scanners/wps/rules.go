@@ -24,6 +24,7 @@ func (a *WebPubSubScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
 				return !ok, ""
 			},
+			Evidence:     []string{"scanContext.DiagnosticsSettings"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-web-pubsub/howto-troubleshoot-resource-logs",
 		},
 		"wps-002": {
@@ -38,6 +39,11 @@ func (a *WebPubSubScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				zones := strings.Contains(sku, "Premium")
 				return !zones, ""
 			},
+			Evidence: []string{"SKU.Name"},
+			Applies: func(target interface{}, scanContext *models.ScanContext) bool {
+				i := target.(*armwebpubsub.ResourceInfo)
+				return models.HasAvailabilityZones(i.Location)
+			},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-web-pubsub/concept-availability-zones",
 		},
 		"wps-003": {
@@ -57,6 +63,7 @@ func (a *WebPubSubScanner) GetRecommendations() map[string]models.AzqrRecommenda
 
 				return sla == "None", sla
 			},
+			Evidence:     []string{"SKU.Name"},
 			LearnMoreUrl: "https://azure.microsoft.com/en-gb/support/legal/sla/web-pubsub/",
 		},
 		"wps-004": {
@@ -70,6 +77,7 @@ func (a *WebPubSubScanner) GetRecommendations() map[string]models.AzqrRecommenda
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
 				return !pe, ""
 			},
+			Evidence:     []string{"Properties.PrivateEndpointConnections"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-web-pubsub/howto-secure-private-endpoints",
 		},
 		"wps-006": {
@@ -80,9 +88,9 @@ func (a *WebPubSubScanner) GetRecommendations() map[string]models.AzqrRecommenda
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armwebpubsub.ResourceInfo)
-				caf := strings.HasPrefix(*c.Name, "wps")
-				return !caf, ""
+				return scanContext.CheckNaming("Microsoft.SignalRService/webPubSub", "wps", *c.Name)
 			},
+			Evidence:     []string{"Name"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"wps-007": {
@@ -93,8 +101,9 @@ func (a *WebPubSubScanner) GetRecommendations() map[string]models.AzqrRecommenda
 			Impact:           models.ImpactLow,
 			Eval: func(target interface{}, scanContext *models.ScanContext) (bool, string) {
 				c := target.(*armwebpubsub.ResourceInfo)
-				return len(c.Tags) == 0, ""
+				return scanContext.CheckTags(c.ID, c.Tags)
 			},
+			Evidence:     []string{"Tags"},
 			LearnMoreUrl: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
 		},
 	}
@@ -143,3 +143,13 @@ func TestWebPubSubScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestWebPubSubScanner_ZonesApplies(t *testing.T) {
+	s := &WebPubSubScanner{}
+	rule := s.GetRecommendations()["wps-002"]
+	for location, want := range map[string]bool{"westeurope": true, "westcentralus": false} {
+		if got := rule.Applies(&armwebpubsub.ResourceInfo{Location: to.Ptr(location)}, &models.ScanContext{}); got != want {
+			t.Errorf("wps-002 Applies() in %s = %v, want %v", location, got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package testserver
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Seed - Azure estate emulated by the Server
+	Seed struct {
+		Subscriptions []Subscription `yaml:"subscriptions"`
+		// Resources are returned as is by ARM. Each resource must have an id; name, type, resourceGroup
+		// and subscriptionId are derived from it when missing.
+		Resources []Resource `yaml:"resources"`
+		// ResourceGroups are returned as is by ARM, e.g. with tags. The resource groups of the resources
+		// are derived from them when missing.
+		ResourceGroups []Resource `yaml:"resourceGroups"`
+		// DiagnosticSettings lists the IDs of the resources with diagnostic settings
+		DiagnosticSettings []string `yaml:"diagnosticSettings"`
+		Costs              []Cost   `yaml:"costs"`
+		// Graph overrides the rows returned by Resource Graph queries
+		Graph []GraphQuery `yaml:"graph"`
+	}
+
+	// Subscription - Emulated subscription
+	Subscription struct {
+		ID   string `yaml:"id"`
+		Name string `yaml:"name"`
+	}
+
+	// Resource - Emulated resource, in its ARM JSON representation
+	Resource map[string]interface{}
+
+	// Cost - Emulated cost of a service in a subscription
+	Cost struct {
+		SubscriptionID string  `yaml:"subscriptionId"`
+		ServiceName    string  `yaml:"serviceName"`
+		Value          float64 `yaml:"value"`
+		Currency       string  `yaml:"currency"`
+	}
+
+	// GraphQuery - Rows returned by the Resource Graph queries containing Query
+	GraphQuery struct {
+		Query string                   `yaml:"query"`
+		Data  []map[string]interface{} `yaml:"data"`
+	}
+)
+
+// LoadSeed reads a seed from a YAML file
+func LoadSeed(path string) (*Seed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed %s: %w", path, err)
+	}
+	return ParseSeed(data)
+}
+
+// ParseSeed parses a YAML seed
+func ParseSeed(data []byte) (*Seed, error) {
+	var seed Seed
+	if err := yaml.Unmarshal(data, &seed); err != nil {
+		return nil, fmt.Errorf("failed to parse seed: %w", err)
+	}
+
+	for i, r := range seed.Resources {
+		id := r.ID()
+		if id == "" {
+			return nil, fmt.Errorf("resource %d of the seed has no id", i)
+		}
+		setDefault(r, "name", id[strings.LastIndex(id, "/")+1:])
+		setDefault(r, "type", resourceType(id))
+		setDefault(r, "subscriptionId", segmentAfter(id, "subscriptions"))
+		setDefault(r, "resourceGroup", segmentAfter(id, "resourcegroups"))
+	}
+	for i, g := range seed.ResourceGroups {
+		id := g.ID()
+		if id == "" {
+			return nil, fmt.Errorf("resource group %d of the seed has no id", i)
+		}
+		setDefault(g, "name", id[strings.LastIndex(id, "/")+1:])
+		setDefault(g, "type", "Microsoft.Resources/resourceGroups")
+		setDefault(g, "subscriptionId", segmentAfter(id, "subscriptions"))
+	}
+	return &seed, nil
+}
+
+// ID returns the resource ID
+func (r Resource) ID() string {
+	id, _ := r["id"].(string)
+	return id
+}
+
+func (r Resource) field(name string) string {
+	v, _ := r[name].(string)
+	return v
+}
+
+// properties returns the properties of the resource. Nested maps of the seed are decoded as Resource.
+func (r Resource) properties() map[string]interface{} {
+	switch p := r["properties"].(type) {
+	case Resource:
+		return p
+	case map[string]interface{}:
+		return p
+	}
+	return nil
+}
+
+func setDefault(r Resource, key, value string) {
+	if _, ok := r[key]; !ok && value != "" {
+		r[key] = value
+	}
+}
+
+// resourceType returns the type of a resource ID, e.g. Microsoft.Web/sites/config for .../providers/Microsoft.Web/sites/app/config/web
+func resourceType(id string) string {
+	i := strings.LastIndex(strings.ToLower(id), "/providers/")
+	if i < 0 {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(id[i+len("/providers/"):], "/"), "/")
+	if len(segments) < 2 {
+		return ""
+	}
+	t := []string{segments[0]}
+	for j := 1; j < len(segments); j += 2 {
+		t = append(t, segments[j])
+	}
+	return strings.Join(t, "/")
+}
+
+// segmentAfter returns the segment of a resource ID following the given (case insensitive) segment
+func segmentAfter(id, segment string) string {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if strings.EqualFold(segments[i], segment) {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
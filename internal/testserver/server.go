@@ -0,0 +1,430 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package testserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/recording"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type (
+	// Server is a local fake of the Azure Resource Manager and Azure Resource Graph endpoints used by azqr.
+	// It emulates ARM list and get requests, the Resource Graph resources query with skipToken paging and
+	// quota headers, the ARM /batch endpoint used for diagnostic settings and the Cost Management query.
+	Server struct {
+		*httptest.Server
+		seed *Seed
+	}
+
+	// transport sends every request to the Server, regardless of the cloud endpoint it targets
+	transport struct {
+		server *httptest.Server
+		host   string
+		scheme string
+	}
+)
+
+// graphQuota is the x-ms-user-quota-remaining reported for every Resource Graph query
+const graphQuota = 14
+
+// New starts a Server emulating the seed. Call Close when done.
+func New(seed *Seed) *Server {
+	s := &Server{seed: seed}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// NewFromFile starts a Server emulating the YAML seed at path. Call Close when done.
+func NewFromFile(path string) (*Server, error) {
+	seed, err := LoadSeed(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(seed), nil
+}
+
+// Transport returns a transport sending the requests of Azure clients to the Server.
+// Use it as the Transport of arm.ClientOptions or of the scan parameters.
+func (s *Server) Transport() policy.Transporter {
+	u, _ := url.Parse(s.URL)
+	return &transport{server: s.Server, host: u.Host, scheme: u.Scheme}
+}
+
+// Credential returns a credential accepted by the Server
+func (s *Server) Credential() azcore.TokenCredential {
+	return recording.FakeCredential{}
+}
+
+// Do sends the request to the Server
+func (t *transport) Do(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.scheme
+	r.URL.Host = t.host
+	r.Host = t.host
+	return t.server.Client().Do(r)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.ToLower(r.URL.Path), "/")
+
+	switch {
+	case r.Method == http.MethodPost && path == "providers/microsoft.resourcegraph/resources":
+		s.graph(w, r)
+	case r.Method == http.MethodPost && path == "batch":
+		s.batch(w, r)
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/providers/microsoft.costmanagement/query"):
+		s.costs(w, path)
+	case r.Method == http.MethodGet:
+		s.get(w, path)
+	default:
+		notFound(w, r.URL.Path)
+	}
+}
+
+// get emulates ARM list and get requests
+func (s *Server) get(w http.ResponseWriter, path string) {
+	segments := strings.Split(path, "/")
+
+	// subscriptions and resource groups
+	if !strings.Contains(path, "/providers/") {
+		switch {
+		case path == "subscriptions":
+			values := []interface{}{}
+			for _, sub := range s.seed.Subscriptions {
+				values = append(values, subscription(sub))
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"value": values})
+			return
+		case len(segments) == 2 && segments[0] == "subscriptions":
+			for _, sub := range s.seed.Subscriptions {
+				if strings.EqualFold(sub.ID, segments[1]) {
+					writeJSON(w, http.StatusOK, subscription(sub))
+					return
+				}
+			}
+		case len(segments) == 3 && segments[2] == "resourcegroups":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"value": s.resourceGroups(segments[1], "")})
+			return
+		case len(segments) == 4 && segments[2] == "resourcegroups":
+			if groups := s.resourceGroups(segments[1], segments[3]); len(groups) > 0 {
+				writeJSON(w, http.StatusOK, groups[0])
+				return
+			}
+		}
+		notFound(w, path)
+		return
+	}
+
+	i := strings.LastIndex(path, "/providers/")
+	typeSegments := strings.Split(path[i+len("/providers/"):], "/")
+
+	// odd number of segments after providers: get a single resource
+	if len(typeSegments)%2 == 1 {
+		for _, r := range s.seed.Resources {
+			if strings.EqualFold(strings.Trim(r.ID(), "/"), path) {
+				writeJSON(w, http.StatusOK, r)
+				return
+			}
+		}
+		notFound(w, path)
+		return
+	}
+
+	// sites of an App Service plan are linked to the plan by their serverFarmId, not nested in it
+	if len(typeSegments) == 4 && typeSegments[0] == "microsoft.web" && typeSegments[1] == "serverfarms" && typeSegments[3] == "sites" {
+		plan := path[:strings.LastIndex(path, "/")]
+		values := []interface{}{}
+		for _, r := range s.seed.Resources {
+			if farm, _ := r.properties()["serverFarmId"].(string); strings.EqualFold(strings.Trim(farm, "/"), plan) {
+				values = append(values, r)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"value": values})
+		return
+	}
+
+	// even number of segments: list the resources of a type in a scope or under a parent resource
+	prefix := path[:i]
+	if len(typeSegments) > 2 {
+		prefix = path[:strings.LastIndex(path, "/")]
+	}
+	t := resourceType("/" + path + "/name")
+
+	values := []interface{}{}
+	for _, r := range s.seed.Resources {
+		id := strings.Trim(strings.ToLower(r.ID()), "/")
+		if strings.HasPrefix(id, prefix+"/") && strings.EqualFold(resourceType(r.ID()), t) {
+			values = append(values, r)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": values})
+}
+
+// graph emulates the Resource Graph resources query with skipToken paging
+func (s *Server) graph(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Subscriptions []string `json:"subscriptions"`
+		Query         string   `json:"query"`
+		Options       struct {
+			Top       *int    `json:"top"`
+			SkipToken *string `json:"skipToken"`
+		} `json:"options"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, armError("BadRequest", err.Error()))
+		return
+	}
+
+	rows := s.graphRows(request.Query, request.Subscriptions)
+
+	skip := 0
+	if request.Options.SkipToken != nil {
+		skip, _ = strconv.Atoi(*request.Options.SkipToken)
+	}
+	if skip > len(rows) {
+		skip = len(rows)
+	}
+	end := len(rows)
+	if request.Options.Top != nil && skip+*request.Options.Top < end {
+		end = skip + *request.Options.Top
+	}
+
+	response := map[string]interface{}{
+		"totalRecords": len(rows),
+		"count":        end - skip,
+		"data":         rows[skip:end],
+	}
+	if end < len(rows) {
+		response["$skipToken"] = strconv.Itoa(end)
+		response["skipToken"] = strconv.Itoa(end)
+	}
+
+	w.Header().Set("x-ms-user-quota-remaining", strconv.Itoa(graphQuota))
+	w.Header().Set("x-ms-user-quota-resets-after", "00:00:05")
+	writeJSON(w, http.StatusOK, response)
+}
+
+// graphRows returns the rows of a Resource Graph query. Seeded queries take precedence, then the
+// inventory and count queries are computed from the seeded resources. Any other query returns no rows.
+func (s *Server) graphRows(query string, subscriptions []string) []interface{} {
+	inScope := map[string]bool{}
+	for _, sub := range subscriptions {
+		inScope[strings.ToLower(sub)] = true
+	}
+
+	rows := []interface{}{}
+	for _, g := range s.seed.Graph {
+		if strings.Contains(query, g.Query) {
+			for _, row := range g.Data {
+				if id, ok := row["id"].(string); ok && !inScope[strings.ToLower(segmentAfter(id, "subscriptions"))] {
+					continue
+				}
+				rows = append(rows, row)
+			}
+			return rows
+		}
+	}
+
+	resources := []Resource{}
+	for _, r := range s.seed.Resources {
+		if inScope[strings.ToLower(r.field("subscriptionId"))] && strings.Count(resourceType(r.ID()), "/") == 1 {
+			resources = append(resources, r)
+		}
+	}
+
+	switch {
+	// Resource Graph returns resource types in lower case
+	case strings.HasPrefix(query, "resources | project id"):
+		for _, r := range resources {
+			sku, _ := r["sku"].(map[string]interface{})
+			// the inventory query only keeps the properties of the types it lists
+			var properties interface{}
+			if strings.Contains(query, "'"+strings.ToLower(r.field("type"))+"'") {
+				properties = r["properties"]
+			}
+			rows = append(rows, map[string]interface{}{
+				"id":             r.ID(),
+				"subscriptionId": r.field("subscriptionId"),
+				"resourceGroup":  r.field("resourceGroup"),
+				"location":       r.field("location"),
+				"type":           strings.ToLower(r.field("type")),
+				"name":           r.field("name"),
+				"sku_name":       sku["name"],
+				"sku_tier":       sku["tier"],
+				"kind":           r["kind"],
+				"tags":           r["tags"],
+				"properties":     properties,
+			})
+		}
+	case strings.Contains(query, "summarize count() by subscriptionId, type"):
+		rows = count(resources, "subscriptionId", "type")
+	case strings.Contains(query, "summarize count() by type"):
+		rows = count(resources, "type")
+	}
+	return rows
+}
+
+// batch emulates the ARM /batch endpoint for diagnostic settings requests
+func (s *Server) batch(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Requests []struct {
+			HttpMethod  string `json:"httpMethod"`
+			RelativeUrl string `json:"relativeUrl"`
+		} `json:"requests"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, armError("BadRequest", err.Error()))
+		return
+	}
+
+	withSettings := map[string]bool{}
+	for _, id := range s.seed.DiagnosticSettings {
+		withSettings[strings.ToLower(id)] = true
+	}
+
+	responses := []interface{}{}
+	for _, item := range request.Requests {
+		id := strings.Split(item.RelativeUrl, "?")[0]
+		id = strings.TrimSuffix(id, "/providers/microsoft.insights/diagnosticSettings")
+		values := []interface{}{}
+		if withSettings[strings.ToLower(id)] {
+			values = append(values, map[string]interface{}{
+				"id":   id + "/providers/microsoft.insights/diagnosticSettings/default",
+				"name": "default",
+			})
+		}
+		responses = append(responses, map[string]interface{}{
+			"httpStatusCode": http.StatusOK,
+			"content":        map[string]interface{}{"value": values},
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"responses": responses})
+}
+
+// costs emulates the Cost Management query of a subscription
+func (s *Server) costs(w http.ResponseWriter, path string) {
+	subscriptionID := segmentAfter(path, "subscriptions")
+	rows := []interface{}{}
+	for _, c := range s.seed.Costs {
+		if strings.EqualFold(c.SubscriptionID, subscriptionID) {
+			rows = append(rows, []interface{}{c.Value, c.ServiceName, c.Currency})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"properties": map[string]interface{}{
+			"columns": []interface{}{
+				map[string]interface{}{"name": "Cost", "type": "Number"},
+				map[string]interface{}{"name": "ServiceName", "type": "String"},
+				map[string]interface{}{"name": "Currency", "type": "String"},
+			},
+			"rows": rows,
+		},
+	})
+}
+
+// resourceGroups returns the seeded resource groups of a subscription, and the ones derived from the seeded resources
+func (s *Server) resourceGroups(subscriptionID, name string) []interface{} {
+	seen := map[string]bool{}
+	groups := []interface{}{}
+	for _, g := range s.seed.ResourceGroups {
+		rg := g.field("name")
+		if !strings.EqualFold(g.field("subscriptionId"), subscriptionID) || (name != "" && !strings.EqualFold(rg, name)) {
+			continue
+		}
+		seen[strings.ToLower(rg)] = true
+		groups = append(groups, map[string]interface{}(g))
+	}
+	for _, r := range s.seed.Resources {
+		if !strings.EqualFold(r.field("subscriptionId"), subscriptionID) {
+			continue
+		}
+		rg := r.field("resourceGroup")
+		if rg == "" || seen[strings.ToLower(rg)] || (name != "" && !strings.EqualFold(rg, name)) {
+			continue
+		}
+		seen[strings.ToLower(rg)] = true
+		groups = append(groups, map[string]interface{}{
+			"id":       fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionID, rg),
+			"name":     rg,
+			"type":     "Microsoft.Resources/resourceGroups",
+			"location": r.field("location"),
+		})
+	}
+	return groups
+}
+
+func subscription(sub Subscription) map[string]interface{} {
+	return map[string]interface{}{
+		"id":             "/subscriptions/" + sub.ID,
+		"subscriptionId": sub.ID,
+		"displayName":    sub.Name,
+		"state":          "Enabled",
+	}
+}
+
+// count emulates "summarize count() by <fields>" and orders the rows by the same fields
+func count(resources []Resource, fields ...string) []interface{} {
+	counts := map[string]float64{}
+	keys := map[string][]string{}
+	for _, r := range resources {
+		values := []string{}
+		for _, f := range fields {
+			v := r.field(f)
+			// Resource Graph returns resource types in lower case
+			if f == "type" {
+				v = strings.ToLower(v)
+			}
+			values = append(values, v)
+		}
+		key := strings.ToLower(strings.Join(values, "|"))
+		counts[key]++
+		keys[key] = values
+	}
+
+	sorted := make([]string, 0, len(counts))
+	for k := range counts {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	rows := []interface{}{}
+	for _, k := range sorted {
+		row := map[string]interface{}{"count_": counts[k]}
+		for i, f := range fields {
+			row[f] = keys[k][i]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func notFound(w http.ResponseWriter, path string) {
+	writeJSON(w, http.StatusNotFound, armError("ResourceNotFound", fmt.Sprintf("The resource %s was not found.", path)))
+}
+
+func armError(code, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("x-ms-ratelimit-remaining-subscription-reads", "249")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package testserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azqr/internal/graph"
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/scanners/aks"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+const seed = `
+subscriptions:
+  - id: 00000000-0000-0000-0000-000000000001
+    name: test
+resources:
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/private-zonal
+    location: westeurope
+    sku:
+      name: Base
+      tier: Standard
+    properties:
+      enableRBAC: true
+      networkProfile:
+        networkPlugin: azure
+        outboundType: loadBalancer
+      apiServerAccessProfile:
+        enablePrivateCluster: true
+      agentPoolProfiles:
+        - name: system
+          availabilityZones: ["1", "2", "3"]
+  - id: /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/public-free
+    location: westeurope
+    sku:
+      name: Base
+      tier: Free
+    properties:
+      enableRBAC: true
+      networkProfile:
+        networkPlugin: kubenet
+        outboundType: loadBalancer
+      agentPoolProfiles:
+        - name: system
+diagnosticSettings:
+  - /subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg/providers/Microsoft.ContainerService/managedClusters/private-zonal
+`
+
+func TestServer_AKSScenario(t *testing.T) {
+	s, err := ParseSeed([]byte(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := New(s)
+	defer server.Close()
+
+	options := &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Transport: server.Transport(),
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+		},
+	}
+	ctx := context.Background()
+
+	// inventory is served by Resource Graph
+	graphClient, err := graph.NewGraphQuery(server.Credential(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscriptionID := "00000000-0000-0000-0000-000000000001"
+	inventory, err := graphClient.Query(ctx, "resources | project id, subscriptionId, resourceGroup, location, type, name, sku.name, sku.tier, kind", []*string{&subscriptionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inventory.Data) != 2 {
+		t.Errorf("Resource Graph inventory returned %d rows, want 2", len(inventory.Data))
+	}
+
+	scanner := &aks.AKSScanner{}
+	if err := scanner.Init(&models.ScannerConfig{
+		Ctx:            ctx,
+		Cred:           server.Credential(),
+		ClientOptions:  options,
+		SubscriptionID: subscriptionID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := scanner.Scan(&models.ScanContext{
+		Filters:             models.NewFilters(),
+		DiagnosticsSettings: map[string]bool{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cluster string
+		rule    string
+		broken  bool
+		result  string
+	}{
+		{name: "private zonal cluster has 99.95% SLA", cluster: "private-zonal", rule: "aks-003", broken: false, result: "99.95%"},
+		{name: "private cluster is private", cluster: "private-zonal", rule: "aks-004", broken: false, result: ""},
+		{name: "free cluster has no SLA", cluster: "public-free", rule: "aks-003", broken: true, result: "None"},
+		{name: "public cluster is not private", cluster: "public-free", rule: "aks-004", broken: true, result: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, r := range results {
+				if r.ServiceName != tt.cluster {
+					continue
+				}
+				got := r.Recommendations[tt.rule]
+				if got.NotCompliant != tt.broken || got.Result != tt.result {
+					t.Errorf("%s %s = (%v, %s), want (%v, %s)", tt.cluster, tt.rule, got.NotCompliant, got.Result, tt.broken, tt.result)
+				}
+				return
+			}
+			t.Errorf("cluster %s was not scanned", tt.cluster)
+		})
+	}
+}
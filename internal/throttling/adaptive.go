@@ -0,0 +1,215 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package throttling
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// tenantScope is the scope of requests that do not target a subscription
+	tenantScope = "tenant"
+	// lowWatermark is the number of remaining reads below which requests are slowed down
+	lowWatermark = 100
+	// maxDelay is the delay between requests when no reads are remaining
+	maxDelay = 1 * time.Second
+)
+
+type (
+	// AdaptiveLimiter slows down or speeds up ARM requests per subscription, using the remaining reads
+	// and Retry-After headers reported by Azure Resource Manager.
+	// https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/request-limits-and-throttling
+	AdaptiveLimiter struct {
+		mu      sync.Mutex
+		scopes  map[string]*scopeState
+		metrics Metrics
+	}
+
+	// scopeState - Throttling state of a subscription or of the tenant
+	scopeState struct {
+		remaining    int
+		blockedUntil time.Time
+	}
+
+	// Metrics - Throttling metrics collected by the AdaptiveLimiter
+	Metrics struct {
+		// Requests is the number of requests sent
+		Requests int64
+		// Throttled is the number of requests that received a 429 response
+		Throttled int64
+		// Delayed is the number of requests that waited before being sent
+		Delayed int64
+		// TotalDelay is the time requests spent waiting
+		TotalDelay time.Duration
+		// MinRemaining is the lowest number of remaining reads reported per subscription (or "tenant")
+		MinRemaining map[string]int
+	}
+
+	// adaptivePolicy - azcore policy that applies the AdaptiveLimiter to every request attempt
+	adaptivePolicy struct {
+		limiter *AdaptiveLimiter
+	}
+)
+
+// NewAdaptiveLimiter creates an AdaptiveLimiter. Use Policy to apply it to ARM clients.
+func NewAdaptiveLimiter() *AdaptiveLimiter {
+	return &AdaptiveLimiter{
+		scopes: map[string]*scopeState{},
+		metrics: Metrics{
+			MinRemaining: map[string]int{},
+		},
+	}
+}
+
+// Policy returns an azcore policy that applies the limiter. Add it to arm.ClientOptions.PerRetryPolicies
+// so every attempt, including retries, is throttled.
+func (l *AdaptiveLimiter) Policy() policy.Policy {
+	return &adaptivePolicy{limiter: l}
+}
+
+// Wait blocks until a request to the scope can be sent, or until ctx is cancelled
+func (l *AdaptiveLimiter) Wait(ctx context.Context, scope string) error {
+	delay := l.delay(scope)
+	if delay <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	l.metrics.Delayed++
+	l.metrics.TotalDelay += delay
+	l.mu.Unlock()
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Update records a request to the scope and the throttling headers of its response, if any
+func (l *AdaptiveLimiter) Update(scope string, resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.metrics.Requests++
+	if resp == nil {
+		return
+	}
+	state := l.state(scope)
+
+	header := "x-ms-ratelimit-remaining-subscription-reads"
+	if scope == tenantScope {
+		header = "x-ms-ratelimit-remaining-tenant-reads"
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get(header)); err == nil {
+		state.remaining = remaining
+		if lowest, ok := l.metrics.MinRemaining[scope]; !ok || remaining < lowest {
+			l.metrics.MinRemaining[scope] = remaining
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.metrics.Throttled++
+		if retryAfter := parseRetryAfter(resp.Header); retryAfter > 0 {
+			state.blockedUntil = time.Now().Add(retryAfter)
+			log.Debug().Msgf("ARM throttled requests to %s. Waiting %s", scope, retryAfter)
+		}
+	}
+}
+
+// Metrics returns a snapshot of the throttling metrics
+func (l *AdaptiveLimiter) Metrics() Metrics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	m := l.metrics
+	m.MinRemaining = make(map[string]int, len(l.metrics.MinRemaining))
+	for k, v := range l.metrics.MinRemaining {
+		m.MinRemaining[k] = v
+	}
+	return m
+}
+
+// delay returns how long the next request to the scope should wait
+func (l *AdaptiveLimiter) delay(scope string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state := l.state(scope)
+	if wait := time.Until(state.blockedUntil); wait > 0 {
+		return wait
+	}
+
+	// Spread the remaining reads: no delay above the watermark, up to maxDelay when none are left
+	if state.remaining < lowWatermark {
+		return maxDelay * time.Duration(lowWatermark-state.remaining) / lowWatermark
+	}
+	return 0
+}
+
+func (l *AdaptiveLimiter) state(scope string) *scopeState {
+	state, ok := l.scopes[scope]
+	if !ok {
+		// unknown scopes start at full speed until ARM reports their remaining reads
+		state = &scopeState{remaining: lowWatermark}
+		l.scopes[scope] = state
+	}
+	return state
+}
+
+// Do waits for the limiter, sends the request and records the throttling headers of the response
+func (p *adaptivePolicy) Do(req *policy.Request) (*http.Response, error) {
+	scope := requestScope(req.Raw())
+	if err := p.limiter.Wait(req.Raw().Context(), scope); err != nil {
+		return nil, err
+	}
+
+	// failed attempts are recorded too, resp is nil if no response was received
+	resp, err := req.Next()
+	p.limiter.Update(scope, resp)
+	return resp, err
+}
+
+// requestScope returns the subscription ID targeted by the request, or "tenant"
+func requestScope(req *http.Request) string {
+	parts := strings.Split(strings.ToLower(req.URL.Path), "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "subscriptions" && parts[i+1] != "" {
+			return parts[i+1]
+		}
+	}
+	return tenantScope
+}
+
+// parseRetryAfter returns the delay requested by the retry-after-ms, x-ms-retry-after-ms or Retry-After headers
+func parseRetryAfter(header http.Header) time.Duration {
+	for _, h := range []string{"retry-after-ms", "x-ms-retry-after-ms"} {
+		if v, err := strconv.Atoi(header.Get(h)); err == nil && v > 0 {
+			return time.Duration(v) * time.Millisecond
+		}
+	}
+
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
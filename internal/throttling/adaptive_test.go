@@ -0,0 +1,172 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package throttling
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+func TestRequestScope(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/subscriptions/" + subscriptionID + "/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st", subscriptionID},
+		{"/Subscriptions/00000000-0000-0000-0000-00000000000A/providers/Microsoft.Security/pricings", "00000000-0000-0000-0000-00000000000a"},
+		{"/subscriptions", tenantScope},
+		{"/subscriptions/", tenantScope},
+		{"/providers/Microsoft.ResourceGraph/resources", tenantScope},
+		{"/providers/Microsoft.Management/managementGroups/mg/subscriptions", tenantScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := &http.Request{URL: &url.URL{Path: tt.path}}
+			if got := requestScope(req); got != tt.want {
+				t.Errorf("requestScope() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"none", http.Header{}, 0},
+		{"milliseconds", http.Header{"Retry-After-Ms": {"1500"}}, 1500 * time.Millisecond},
+		{"x-ms milliseconds", http.Header{"X-Ms-Retry-After-Ms": {"200"}}, 200 * time.Millisecond},
+		{"milliseconds first", http.Header{"Retry-After-Ms": {"100"}, "Retry-After": {"10"}}, 100 * time.Millisecond},
+		{"seconds", http.Header{"Retry-After": {"17"}}, 17 * time.Second},
+		{"invalid", http.Header{"Retry-After": {"soon"}}, 0},
+		{"invalid milliseconds", http.Header{"Retry-After-Ms": {"-1"}, "Retry-After": {"2"}}, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.header); got != tt.want {
+				t.Errorf("parseRetryAfter() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// HTTP dates are relative to now
+	date := http.Header{"Retry-After": {time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}}
+	if got := parseRetryAfter(date); got <= 50*time.Second || got > time.Minute {
+		t.Errorf("parseRetryAfter() = %s for a date in a minute", got)
+	}
+}
+
+func TestAdaptiveLimiter_Delay(t *testing.T) {
+	tests := []struct {
+		name      string
+		scope     string
+		header    string
+		remaining int
+		want      time.Duration
+	}{
+		{"above watermark", subscriptionID, "x-ms-ratelimit-remaining-subscription-reads", 11999, 0},
+		{"at watermark", subscriptionID, "x-ms-ratelimit-remaining-subscription-reads", lowWatermark, 0},
+		{"half of watermark", subscriptionID, "x-ms-ratelimit-remaining-subscription-reads", lowWatermark / 2, maxDelay / 2},
+		{"none remaining", subscriptionID, "x-ms-ratelimit-remaining-subscription-reads", 0, maxDelay},
+		{"tenant", tenantScope, "x-ms-ratelimit-remaining-tenant-reads", 0, maxDelay},
+		{"tenant header ignored for subscriptions", subscriptionID, "x-ms-ratelimit-remaining-tenant-reads", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewAdaptiveLimiter()
+			resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+			resp.Header.Set(tt.header, strconv.Itoa(tt.remaining))
+			l.Update(tt.scope, resp)
+
+			if got := l.delay(tt.scope); got != tt.want {
+				t.Errorf("delay() = %s, want %s", got, tt.want)
+			}
+			// other scopes are not slowed down
+			if got := l.delay("other"); got != 0 {
+				t.Errorf("delay() of another scope = %s, want 0", got)
+			}
+		})
+	}
+}
+
+func TestAdaptiveLimiter_Throttled(t *testing.T) {
+	l := NewAdaptiveLimiter()
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "30")
+	resp.Header.Set("x-ms-ratelimit-remaining-subscription-reads", "500")
+	l.Update(subscriptionID, resp)
+
+	if got := l.delay(subscriptionID); got <= 29*time.Second || got > 30*time.Second {
+		t.Errorf("delay() = %s after a 429 with Retry-After: 30", got)
+	}
+
+	// the wait is interrupted by the context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx, subscriptionID); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() = %v, want context.Canceled", err)
+	}
+
+	m := l.Metrics()
+	if m.Requests != 1 || m.Throttled != 1 || m.Delayed != 1 || m.MinRemaining[subscriptionID] != 500 {
+		t.Errorf("Metrics() = %+v, want 1 request, 1 throttled, 1 delayed and 500 remaining", m)
+	}
+}
+
+type fakeTransport struct {
+	resp *http.Response
+	err  error
+}
+
+func (f *fakeTransport) Do(req *http.Request) (*http.Response, error) {
+	if f.resp != nil {
+		f.resp.Request = req
+	}
+	return f.resp, f.err
+}
+
+func TestPolicy_Do(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport *fakeTransport
+		wantErr   bool
+	}{
+		{"response", &fakeTransport{resp: &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}}, false},
+		{"transport error", &fakeTransport{err: errors.New("connection reset")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewAdaptiveLimiter()
+			pl := runtime.NewPipeline("azqr", "test", runtime.PipelineOptions{PerRetry: []policy.Policy{l.Policy()}}, &policy.ClientOptions{
+				Transport: tt.transport,
+				Retry:     policy.RetryOptions{MaxRetries: -1},
+			})
+			req, err := runtime.NewRequest(context.Background(), http.MethodGet, "https://management.azure.com/subscriptions/"+subscriptionID+"/resourceGroups")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = pl.Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// failed requests are counted too
+			if m := l.Metrics(); m.Requests != 1 {
+				t.Errorf("Metrics().Requests = %d, want 1", m.Requests)
+			}
+		})
+	}
+}
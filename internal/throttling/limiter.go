@@ -1,6 +1,9 @@
 package throttling
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Limiter struct {
 	bucketCapacity int
@@ -18,7 +21,8 @@ func NewLimiter(bucketCapacity, refillRate int, refillEvery, refillWait time.Dur
 	}
 }
 
-func (l *Limiter) Start() chan struct{} {
+// Start fills the burst limiter channel and refills it until ctx is cancelled
+func (l *Limiter) Start(ctx context.Context) chan struct{} {
 	bucketCapacity := l.bucketCapacity
 	refillRate := l.refillRate
 
@@ -40,7 +44,13 @@ func (l *Limiter) Start() chan struct{} {
 
 	// Start a goroutine to send ticks to the burstLimiter channel
 	go func() {
-		for range limiter.C {
+		defer limiter.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-limiter.C:
+			}
 			for i := 0; i < refillRate; i++ {
 				// Only add a token if the channel is not full to avoid blocking
 				select {
@@ -22,7 +22,8 @@ func String(i interface{}) string {
 	default:
 		jsonStr, err := json.Marshal(i)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Unsupported type found in ARG query result")
+			log.Warn().Err(err).Msg("Unsupported type found in ARG query result")
+			return fmt.Sprintf("%v", i)
 		}
 		return string(jsonStr)
 	}
@@ -0,0 +1,273 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+// Package trends keeps the summarised metrics of every scan in a JSON Lines
+// file and aggregates them per week to render trend reports.
+package trends
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+)
+
+type (
+	// Record - Summarised metrics of a scan, one line of the trend store
+	Record struct {
+		Time          time.Time             `json:"time"`
+		Subscriptions []SubscriptionMetrics `json:"subscriptions"`
+	}
+
+	// SubscriptionMetrics - Open findings and Defender plans of a subscription
+	SubscriptionMetrics struct {
+		SubscriptionID   string         `json:"subscriptionId"`
+		SubscriptionName string         `json:"subscriptionName"`
+		Findings         []FindingCount `json:"findings"`
+		DefenderPlans    int            `json:"defenderPlans"`
+		// DefenderPlansEnabled is the number of Defender plans in the Standard tier
+		DefenderPlansEnabled int `json:"defenderPlansEnabled"`
+	}
+
+	// FindingCount - Number of open findings of a source, category and impact
+	FindingCount struct {
+		Source   models.FindingSource `json:"source"`
+		Category string               `json:"category"`
+		Impact   string               `json:"impact"`
+		Count    int                  `json:"count"`
+	}
+)
+
+// Summarize counts the open findings by source, category and impact, and the Defender plans of every subscription.
+// subscriptions are the scanned subscriptions, keyed by ID: they are reported even without findings.
+func Summarize(data *renderers.ReportData, subscriptions map[string]string, now time.Time) Record {
+	metrics := map[string]*SubscriptionMetrics{}
+	subscription := func(id, name string) *SubscriptionMetrics {
+		key := strings.ToLower(id)
+		s, ok := metrics[key]
+		if !ok {
+			s = &SubscriptionMetrics{
+				SubscriptionID:   renderers.MaskSubscriptionID(id, data.Mask),
+				SubscriptionName: name,
+				Findings:         []FindingCount{},
+			}
+			metrics[key] = s
+		}
+		return s
+	}
+	for id, name := range subscriptions {
+		subscription(id, name)
+	}
+
+	counts := map[string]map[FindingCount]int{}
+	count := func(id, name string, source models.FindingSource, category, impact string) {
+		subscription(id, name)
+		key := strings.ToLower(id)
+		if counts[key] == nil {
+			counts[key] = map[FindingCount]int{}
+		}
+		counts[key][FindingCount{Source: source, Category: category, Impact: impact}]++
+	}
+
+	for _, d := range data.Azqr {
+		for _, r := range d.Recommendations {
+			if r.NotCompliant {
+				count(d.SubscriptionID, d.SubscriptionName, models.FindingSourceAzqr, string(r.Category), string(r.Impact))
+			}
+		}
+	}
+	for _, r := range data.Aprl {
+		count(r.SubscriptionID, r.SubscriptionName, models.FindingSourceAprl, string(r.Category), string(r.Impact))
+	}
+	for _, r := range data.Advisor {
+		count(r.SubscriptionID, r.SubscriptionName, models.FindingSourceAdvisor, r.Category, r.Impact)
+	}
+	for _, r := range data.DefenderRecommendations {
+		count(r.SubscriptionId, r.SubscriptionName, models.FindingSourceDefender, r.Category, r.RecommendationSeverity)
+	}
+
+	for _, d := range data.Defender {
+		s := subscription(d.SubscriptionID, d.SubscriptionName)
+		s.DefenderPlans++
+		if strings.EqualFold(d.Tier, "Standard") {
+			s.DefenderPlansEnabled++
+		}
+	}
+
+	record := Record{Time: now.UTC(), Subscriptions: []SubscriptionMetrics{}}
+	for key, s := range metrics {
+		for c, n := range counts[key] {
+			c.Count = n
+			s.Findings = append(s.Findings, c)
+		}
+		sort.Slice(s.Findings, func(i, j int) bool {
+			a, b := s.Findings[i], s.Findings[j]
+			if a.Source != b.Source {
+				return a.Source < b.Source
+			}
+			if a.Category != b.Category {
+				return a.Category < b.Category
+			}
+			return a.Impact < b.Impact
+		})
+		record.Subscriptions = append(record.Subscriptions, *s)
+	}
+	sort.Slice(record.Subscriptions, func(i, j int) bool {
+		a, b := record.Subscriptions[i], record.Subscriptions[j]
+		if a.SubscriptionName != b.SubscriptionName {
+			return a.SubscriptionName < b.SubscriptionName
+		}
+		return a.SubscriptionID < b.SubscriptionID
+	})
+	return record
+}
+
+// Append appends a record to the trend store, creating the file if needed
+func Append(file string, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trend record: %w", err)
+	}
+
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open trend store %s: %w", file, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write trend store %s: %w", file, err)
+	}
+	return nil
+}
+
+// Load reads all records of the trend store
+func Load(file string) ([]Record, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trend store %s: %w", file, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	records := []Record{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("failed parsing line %d of trend store %s: %w", line, file, err)
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trend store %s: %w", file, err)
+	}
+	return records, nil
+}
+
+// Weekly aggregates the records per ISO week. The last scan of a subscription in a week is its metrics for the week.
+// Subscriptions are identified by their ID and labelled with their latest name, followed by their ID when several share it.
+func Weekly(records []Record) *renderers.TrendsData {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+
+	// latest metrics per week and subscription ID
+	latest := map[string]map[string]SubscriptionMetrics{}
+	weeks := []string{}
+	names := map[string]string{}
+	for _, r := range records {
+		year, week := r.Time.ISOWeek()
+		w := fmt.Sprintf("%d-W%02d", year, week)
+		if latest[w] == nil {
+			latest[w] = map[string]SubscriptionMetrics{}
+			weeks = append(weeks, w)
+		}
+		for _, s := range r.Subscriptions {
+			id := strings.ToLower(s.SubscriptionID)
+			latest[w][id] = s
+			names[id] = s.SubscriptionName
+		}
+	}
+
+	// subscriptions sharing a name are told apart by their ID
+	shared := map[string]int{}
+	for _, name := range names {
+		shared[name]++
+	}
+	labels := map[string]string{}
+	for id, name := range names {
+		switch {
+		case name == "":
+			labels[id] = id
+		case shared[name] > 1:
+			labels[id] = fmt.Sprintf("%s (%s)", name, id)
+		default:
+			labels[id] = name
+		}
+	}
+	ids := make([]string, 0, len(labels))
+	for id := range labels {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return labels[ids[i]] < labels[ids[j]]
+	})
+
+	data := &renderers.TrendsData{
+		Weeks:            weeks,
+		Subscriptions:    []string{},
+		HighImpact:       map[string]map[string]int{},
+		DefenderCoverage: map[string]map[string]float64{},
+		Findings:         []renderers.TrendFindingCount{},
+	}
+	for _, id := range ids {
+		data.Subscriptions = append(data.Subscriptions, labels[id])
+	}
+
+	for _, w := range weeks {
+		data.HighImpact[w] = map[string]int{}
+		data.DefenderCoverage[w] = map[string]float64{}
+		for _, id := range ids {
+			s, ok := latest[w][id]
+			if !ok {
+				continue
+			}
+			label := labels[id]
+
+			high := 0
+			for _, f := range s.Findings {
+				if strings.EqualFold(f.Impact, string(models.ImpactHigh)) {
+					high += f.Count
+				}
+				data.Findings = append(data.Findings, renderers.TrendFindingCount{
+					Week:         w,
+					Subscription: label,
+					Source:       string(f.Source),
+					Category:     f.Category,
+					Impact:       f.Impact,
+					Count:        f.Count,
+				})
+			}
+			data.HighImpact[w][label] = high
+
+			if s.DefenderPlans > 0 {
+				data.DefenderCoverage[w][label] = float64(s.DefenderPlansEnabled) * 100 / float64(s.DefenderPlans)
+			}
+		}
+	}
+	return data
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package trends
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+)
+
+const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+func scan(high int, standardPlans int) *renderers.ReportData {
+	data := renderers.NewReportData("", true)
+	for i := 0; i < high; i++ {
+		data.Aprl = append(data.Aprl, models.AprlResult{SubscriptionID: subscriptionID, SubscriptionName: "prod", Category: models.CategoryHighAvailability, Impact: models.ImpactHigh})
+	}
+	data.Advisor = append(data.Advisor, models.AdvisorResult{SubscriptionID: subscriptionID, SubscriptionName: "prod", Category: "Cost", Impact: "Low"})
+	for i, tier := range []string{"Free", "Free", "Free", "Free"} {
+		if i < standardPlans {
+			tier = "Standard"
+		}
+		data.Defender = append(data.Defender, models.DefenderResult{SubscriptionID: subscriptionID, SubscriptionName: "prod", Tier: tier})
+	}
+	return &data
+}
+
+func TestWeekly(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "trends.jsonl")
+	monday := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	// the last scan of a week is the one reported
+	for _, s := range []struct {
+		time     time.Time
+		high     int
+		standard int
+	}{
+		{monday, 5, 1},
+		{monday.AddDate(0, 0, 2), 4, 2},
+		{monday.AddDate(0, 0, 7), 1, 4},
+	} {
+		if err := Append(file, Summarize(scan(s.high, s.standard), map[string]string{subscriptionID: "prod"}, s.time)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := Weekly(records)
+
+	if len(data.Weeks) != 2 || data.Weeks[0] != "2025-W02" || data.Weeks[1] != "2025-W03" {
+		t.Fatalf("Weeks = %v, want [2025-W02 2025-W03]", data.Weeks)
+	}
+	if data.HighImpact["2025-W02"]["prod"] != 4 || data.HighImpact["2025-W03"]["prod"] != 1 {
+		t.Errorf("HighImpact = %v, want 4 and 1", data.HighImpact)
+	}
+	if data.DefenderCoverage["2025-W02"]["prod"] != 50 || data.DefenderCoverage["2025-W03"]["prod"] != 100 {
+		t.Errorf("DefenderCoverage = %v, want 50 and 100", data.DefenderCoverage)
+	}
+	if len(data.Findings) != 4 {
+		t.Errorf("got %d finding counts, want 4", len(data.Findings))
+	}
+}
+
+func TestWeekly_Subscriptions(t *testing.T) {
+	const otherID = "11111111-1111-1111-1111-111111111111"
+	subscriptions := map[string]string{subscriptionID: "prod", otherID: "prod"}
+	monday := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	// both subscriptions are named prod, and the findings of the second are resolved the week after
+	first := scan(2, 0)
+	first.Aprl = append(first.Aprl, models.AprlResult{SubscriptionID: otherID, SubscriptionName: "prod", Category: models.CategoryHighAvailability, Impact: models.ImpactHigh})
+	second := renderers.NewReportData("", true)
+	second.Aprl = append(second.Aprl, models.AprlResult{SubscriptionID: subscriptionID, SubscriptionName: "prod", Category: models.CategoryHighAvailability, Impact: models.ImpactHigh})
+
+	records := []Record{
+		Summarize(first, subscriptions, monday),
+		Summarize(&second, subscriptions, monday.AddDate(0, 0, 7)),
+	}
+	data := Weekly(records)
+
+	prod := "prod (" + renderers.MaskSubscriptionID(subscriptionID, true) + ")"
+	other := "prod (" + renderers.MaskSubscriptionID(otherID, true) + ")"
+	if len(data.Subscriptions) != 2 || data.Subscriptions[0] != prod || data.Subscriptions[1] != other {
+		t.Fatalf("Subscriptions = %v, want [%s %s]", data.Subscriptions, prod, other)
+	}
+	if data.HighImpact["2025-W02"][prod] != 2 || data.HighImpact["2025-W02"][other] != 1 {
+		t.Errorf("HighImpact of 2025-W02 = %v, want 2 and 1", data.HighImpact["2025-W02"])
+	}
+	if n, ok := data.HighImpact["2025-W03"][other]; !ok || n != 0 {
+		t.Errorf("HighImpact of %s in 2025-W03 = %d (reported %t), want 0", other, n, ok)
+	}
+}
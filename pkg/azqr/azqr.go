@@ -0,0 +1,74 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+// Package azqr exposes Azure Quick Review scans to Go programs.
+//
+// Unlike the azqr command line, functions in this package never exit the
+// process: failures are returned as errors and rendering of the collected
+// data is left to the caller.
+package azqr
+
+import (
+	"context"
+
+	"github.com/Azure/azqr/internal"
+	"github.com/Azure/azqr/internal/models"
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/renderers/csv"
+	"github.com/Azure/azqr/internal/renderers/excel"
+	"github.com/Azure/azqr/internal/renderers/json"
+)
+
+type (
+	// ScanParams - Parameters of a scan
+	ScanParams = internal.ScanParams
+
+	// ReportData - Data collected by a scan
+	ReportData = renderers.ReportData
+
+	// Filters - Filters applied to a scan
+	Filters = models.Filters
+)
+
+// NewScanParams returns scan parameters with all scanners enabled and default filters.
+func NewScanParams() *ScanParams {
+	params := internal.NewScanParams()
+	params.ScannerKeys = ScannerKeys()
+	params.Filters, _ = models.LoadFilters("", params.ScannerKeys)
+	return params
+}
+
+// ScannerKeys returns the abbreviations of all supported service scanners.
+func ScannerKeys() []string {
+	keys, _ := models.GetScanners()
+	return keys
+}
+
+// LoadFilters loads filters from a YAML file for the given scanner keys.
+// If filterFile is empty, default filters are returned.
+func LoadFilters(filterFile string, scannerKeys []string) (*Filters, error) {
+	return models.LoadFilters(filterFile, scannerKeys)
+}
+
+// Scan scans Azure resources and returns the collected report data.
+// If ctx is cancelled, the data collected so far is returned, marked as
+// Incomplete, together with the context error.
+func Scan(ctx context.Context, params *ScanParams) (*ReportData, error) {
+	scanner := internal.Scanner{}
+	return scanner.ScanReport(ctx, params)
+}
+
+// RenderExcel writes the report data to <OutputFileName>.xlsx.
+func RenderExcel(data *ReportData) error {
+	return excel.CreateExcelReport(data)
+}
+
+// RenderJSON writes the report data to <OutputFileName>.<table>.json files.
+func RenderJSON(data *ReportData) error {
+	return json.CreateJsonReport(data)
+}
+
+// RenderCSV writes the report data to <OutputFileName>.<table>.csv files.
+func RenderCSV(data *ReportData) error {
+	return csv.CreateCsvReport(data)
+}